@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/sehogas/goarca/internal/dto"
+	"github.com/sehogas/goarca/internal/middleware"
+	"github.com/sehogas/goarca/internal/services"
+	"github.com/sehogas/goarca/internal/util"
+)
+
+// AdminTicketsHandler godoc
+//
+//	@Summary		Tickets de acceso
+//	@Description	Lista el estado de los tickets de acceso de WSAA por servicio y CUIT, sin token ni firma. Requiere una API Key de administración.
+//	@Tags			Administración
+//	@Produce		json
+//	@Param			x-api-key	header		string	true	"API Key de administración"
+//	@Param			cuit		query		string	false	"Filtra por CUIT"
+//	@Success		200			{array}		dto.TicketInfo
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Router			/admin/tickets [get]
+func AdminTicketsHandler(w http.ResponseWriter, r *http.Request) {
+	var cuit int64
+	if r.URL.Query().Get("cuit") != "" {
+		var err error
+		cuit, err = strconv.ParseInt(r.URL.Query().Get("cuit"), 10, 64)
+		if err != nil {
+			err := errors.New("error leyendo parámetro cuit")
+			util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+			return
+		}
+	}
+
+	allowed := middleware.AllowedCuits(r)
+	result := []dto.TicketInfo{}
+	for _, t := range sortedTenants() {
+		if (cuit != 0 && t.Cuit != cuit) || (len(allowed) > 0 && !slices.Contains(allowed, t.Cuit)) {
+			continue
+		}
+		for _, target := range t.RenewalTargets() {
+			result = append(result, ticketInfo(target.ServiceName, t.Cuit))
+		}
+	}
+	util.HttpResponseJSON(w, http.StatusOK, result, nil)
+}
+
+// AdminInvalidateTicketHandler godoc
+//
+//	@Summary		Descartar ticket de acceso
+//	@Description	Descarta el ticket de acceso del servicio en memoria y en el almacenamiento; el próximo uso solicitará uno nuevo a WSAA. Requiere una API Key de administración.
+//	@Tags			Administración
+//	@Produce		json
+//	@Param			x-api-key	header		string	true	"API Key de administración"
+//	@Param			cuit		path		string	true	"CUIT"
+//	@Param			service		path		string	true	"Servicio (wsfe, wgescomunicacionembarque, wconscomunicacionembarque, wGesTabRef)"
+//	@Success		200			{object}	dto.MessageResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Router			/admin/tickets/{cuit}/{service} [delete]
+func AdminInvalidateTicketHandler(w http.ResponseWriter, r *http.Request) {
+	target, status, err := adminTicketTarget(r)
+	if err != nil {
+		util.HttpResponseJSON(w, status, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	if err := services.InvalidateTA(target.ServiceName, target.Tenant.Cuit); err != nil {
+		HttpResponseError(w, err)
+		return
+	}
+	util.HttpResponseJSON(w, http.StatusOK, &dto.MessageResponse{Message: "ticket de acceso descartado"}, nil)
+}
+
+// AdminRenewTicketHandler godoc
+//
+//	@Summary		Renovar ticket de acceso
+//	@Description	Solicita a WSAA un nuevo ticket de acceso para el servicio aunque el actual siga vigente, por ejemplo después de rotar el certificado. Si WSAA lo rechaza se conserva el ticket actual. Requiere una API Key de administración.
+//	@Tags			Administración
+//	@Produce		json
+//	@Param			x-api-key	header		string	true	"API Key de administración"
+//	@Param			cuit		path		string	true	"CUIT"
+//	@Param			service		path		string	true	"Servicio (wsfe, wgescomunicacionembarque, wconscomunicacionembarque, wGesTabRef)"
+//	@Success		200			{object}	dto.TicketInfo
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Router			/admin/tickets/{cuit}/{service}/renew [post]
+func AdminRenewTicketHandler(w http.ResponseWriter, r *http.Request) {
+	target, status, err := adminTicketTarget(r)
+	if err != nil {
+		util.HttpResponseJSON(w, status, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	if _, err := services.RenewTA(Environment, target.ServiceName, target.Tenant); err != nil {
+		HttpResponseError(w, err)
+		return
+	}
+	info := ticketInfo(target.ServiceName, target.Tenant.Cuit)
+	util.HttpResponseJSON(w, http.StatusOK, &info, nil)
+}
+
+// adminTicketTarget devuelve el servicio del CUIT indicados en la ruta, junto con el código HTTP si no es válido.
+func adminTicketTarget(r *http.Request) (*services.RenewalTarget, int, error) {
+	cuit, err := strconv.ParseInt(r.PathValue("cuit"), 10, 64)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("CUIT inválido")
+	}
+	if allowed := middleware.AllowedCuits(r); len(allowed) > 0 && !slices.Contains(allowed, cuit) {
+		return nil, http.StatusForbidden, middleware.ErrInvalidTenant
+	}
+	t, exist := Tenants[cuit]
+	if !exist {
+		return nil, http.StatusNotFound, middleware.ErrInvalidTenant
+	}
+	for _, target := range t.RenewalTargets() {
+		if target.ServiceName == r.PathValue("service") {
+			return &target, 0, nil
+		}
+	}
+	return nil, http.StatusNotFound, errors.New("servicio no habilitado para el CUIT")
+}
+
+func ticketInfo(serviceName string, cuit int64) dto.TicketInfo {
+	info, err := services.InspectTA(serviceName, cuit)
+	switch {
+	case errors.Is(err, services.ErrTicketNotFound):
+		return dto.TicketInfo{ServiceName: serviceName, Cuit: cuit, Source: "none"}
+	case err != nil:
+		return dto.TicketInfo{ServiceName: serviceName, Cuit: cuit, Source: "none", Error: err.Error()}
+	}
+	return dto.TicketInfo{
+		ServiceName:    info.ServiceName,
+		Cuit:           info.Cuit,
+		GenerationTime: info.GenerationTime,
+		ExpirationTime: info.ExpirationTime,
+		Source:         info.Source,
+		Valid:          info.Valid,
+		BlockedUntil:   info.BlockedUntil,
+	}
+}
@@ -17,18 +17,24 @@ import (
 //	@Tags			Comunicación de Embarque
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wscoem.ResultadoEjecucionDummy
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //
 //	@Router			/coem/Dummy [get]
 func DummyCoemHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wscoem.Dummy()
+	resultado, err := Wscoem(r).Dummy(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // RegistrarCaratulaHandler godoc
@@ -39,11 +45,18 @@ func DummyCoemHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string							true	"API Key de acceso"
+//	@Param			x-cuit		header		string							false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool							false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.RegistrarCaratulaRequest	true	"RegistrarCaratulaRequest"
 //	@Success		200			{object}	dto.MessageResponse
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/RegistrarCaratula [post]
 func RegistrarCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.RegistrarCaratulaRequest
@@ -55,13 +68,13 @@ func RegistrarCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Println(post)
 
-	resultado, err := Wscoem.RegistrarCaratula(&post)
+	resultado, err := Wscoem(r).RegistrarCaratula(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // AnularCaratulaHandler godoc
@@ -72,11 +85,18 @@ func RegistrarCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string							true	"API Key de acceso"
+//	@Param			x-cuit		header		string							false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool							false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.AnularCaratulaRequest	true	"AnularCaratulaRequest"
 //	@Success		200			{object}	wscoem.AnularEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/AnularCaratula [delete]
 func AnularCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.AnularCaratulaRequest
@@ -86,15 +106,15 @@ func AnularCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.AnularCaratula(&wscoem.AnularCaratulaRequest{
+	resultado, err := Wscoem(r).AnularCaratula(r.Context(), &wscoem.AnularCaratulaRequest{
 		IdentificadorCaratula: post.IdentificadorCaratula,
 	})
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // RectificarCaratulaHandler godoc
@@ -105,11 +125,18 @@ func AnularCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string								true	"API Key de acceso"
+//	@Param			x-cuit		header		string								false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool								false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.RectificarCaratulaRequest	true	"RectificarCaratulaRequest"
 //	@Success		200			{object}	wscoem.RectificarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/RectificarCaratula [put]
 func RectificarCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.RectificarCaratulaRequest
@@ -119,13 +146,13 @@ func RectificarCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.RectificarCaratula(&post)
+	resultado, err := Wscoem(r).RectificarCaratula(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // RegistrarCOEMHandler godoc
@@ -136,11 +163,18 @@ func RectificarCaratulaHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string						true	"API Key de acceso"
+//	@Param			x-cuit		header		string						false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool						false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.RegistrarCOEMRequest	true	"RegistrarCOEMRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/RegistrarCOEM [post]
 func RegistrarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.RegistrarCOEMRequest
@@ -150,13 +184,13 @@ func RegistrarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.RegistrarCOEM(&post)
+	resultado, err := Wscoem(r).RegistrarCOEM(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // SolicitarCambioBuqueHandler godoc
@@ -167,11 +201,18 @@ func RegistrarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string								true	"API Key de acceso"
+//	@Param			x-cuit		header		string								false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool								false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.SolicitarCambioBuqueRequest	true	"SolicitarCambioBuqueRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/SolicitarCambioBuque [put]
 func SolicitarCambioBuqueHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.SolicitarCambioBuqueRequest
@@ -181,13 +222,13 @@ func SolicitarCambioBuqueHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.SolicitarCambioBuque(&post)
+	resultado, err := Wscoem(r).SolicitarCambioBuque(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // SolicitarCambioFechasHandler godoc
@@ -198,11 +239,18 @@ func SolicitarCambioBuqueHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string								true	"API Key de acceso"
+//	@Param			x-cuit		header		string								false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool								false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.SolicitarCambioFechasRequest	true	"SolicitarCambioFechasRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/SolicitarCambioFechas [put]
 func SolicitarCambioFechasHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.SolicitarCambioFechasRequest
@@ -212,13 +260,13 @@ func SolicitarCambioFechasHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.SolicitarCambioFechas(&post)
+	resultado, err := Wscoem(r).SolicitarCambioFechas(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // SolicitarCambioLOTHandler godoc
@@ -229,11 +277,18 @@ func SolicitarCambioFechasHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string								true	"API Key de acceso"
+//	@Param			x-cuit		header		string								false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool								false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.SolicitarCambioLOTRequest	true	"SolicitarCambioLOTRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/SolicitarCambioLOT [put]
 func SolicitarCambioLOTHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.SolicitarCambioLOTRequest
@@ -242,13 +297,13 @@ func SolicitarCambioLOTHandler(w http.ResponseWriter, r *http.Request) {
 		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: "error leyendo parámetros de la solicitud"}, err)
 		return
 	}
-	resultado, err := Wscoem.SolicitarCambioLOT(&post)
+	resultado, err := Wscoem(r).SolicitarCambioLOT(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // RectificarCOEMHandler godoc
@@ -259,11 +314,18 @@ func SolicitarCambioLOTHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string							true	"API Key de acceso"
+//	@Param			x-cuit		header		string							false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool							false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.RectificarCOEMRequest	true	"RectificarCOEMRequest"
 //	@Success		200			{object}	wscoem.RectificarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/RectificarCOEM [put]
 func RectificarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.RectificarCOEMRequest
@@ -273,13 +335,13 @@ func RectificarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.RectificarCOEM(&post)
+	resultado, err := Wscoem(r).RectificarCOEM(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // CerrarCOEMHandler godoc
@@ -290,11 +352,18 @@ func RectificarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string						true	"API Key de acceso"
+//	@Param			x-cuit		header		string						false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool						false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.CerrarCOEMRequest	true	"CerrarCOEMRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/CerrarCOEM [post]
 func CerrarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.CerrarCOEMRequest
@@ -304,13 +373,13 @@ func CerrarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.CerrarCOEM(&post)
+	resultado, err := Wscoem(r).CerrarCOEM(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // AnularCOEMHandler godoc
@@ -321,11 +390,18 @@ func CerrarCOEMHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string						true	"API Key de acceso"
+//	@Param			x-cuit		header		string						false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool						false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.AnularCOEMRequest	true	"AnularCOEMRequest"
 //	@Success		200			{object}	wscoem.AnularEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/AnularCOEM [delete]
 func AnularCOEMHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.AnularCOEMRequest
@@ -335,13 +411,13 @@ func AnularCOEMHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.AnularCOEM(&post)
+	resultado, err := Wscoem(r).AnularCOEM(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // SolicitarAnulacionCOEMHandler godoc
@@ -352,11 +428,18 @@ func AnularCOEMHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string									true	"API Key de acceso"
+//	@Param			x-cuit		header		string									false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool									false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.SolicitarAnulacionCOEMRequest	true	"SolicitarAnulacionCOEMRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/SolicitarAnulacionCOEM [post]
 func SolicitarAnulacionCOEMHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.SolicitarAnulacionCOEMRequest
@@ -366,13 +449,13 @@ func SolicitarAnulacionCOEMHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.SolicitarAnulacionCOEM(&post)
+	resultado, err := Wscoem(r).SolicitarAnulacionCOEM(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // SolicitarNoABordoHandler godoc
@@ -383,11 +466,18 @@ func SolicitarAnulacionCOEMHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string							true	"API Key de acceso"
+//	@Param			x-cuit		header		string							false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool							false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.SolicitarNoABordoRequest	true	"SolicitarNoABordoRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/SolicitarNoABordo [post]
 func SolicitarNoABordoHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.SolicitarNoABordoRequest
@@ -397,13 +487,13 @@ func SolicitarNoABordoHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoem.SolicitarNoABordo(&post)
+	resultado, err := Wscoem(r).SolicitarNoABordo(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // SolicitarCierreCargaContoBultoHandler godoc
@@ -414,11 +504,18 @@ func SolicitarNoABordoHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string											true	"API Key de acceso"
+//	@Param			x-cuit		header		string											false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool											false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.SolicitarCierreCargaContoBultoRequest	true	"SolicitarCierreCargaContoBultoRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/SolicitarCierreCargaContoBulto [post]
 func SolicitarCierreCargaContoBultoHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.SolicitarCierreCargaContoBultoRequest
@@ -428,13 +525,13 @@ func SolicitarCierreCargaContoBultoHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resultado, err := Wscoem.SolicitarCierreCargaContoBulto(&post)
+	resultado, err := Wscoem(r).SolicitarCierreCargaContoBulto(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // SolicitarCierreCargaGranelHandler godoc
@@ -445,11 +542,18 @@ func SolicitarCierreCargaContoBultoHandler(w http.ResponseWriter, r *http.Reques
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string										true	"API Key de acceso"
+//	@Param			x-cuit		header		string										false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool										false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		wscoem.SolicitarCierreCargaGranelRequest	true	"SolicitarCierreCargaGranelRequest"
 //	@Success		200			{object}	wscoem.RegistrarEmbarqueRta
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coem/SolicitarCierreCargaGranel [post]
 func SolicitarCierreCargaGranelHandler(w http.ResponseWriter, r *http.Request) {
 	var post wscoem.SolicitarCierreCargaGranelRequest
@@ -459,11 +563,11 @@ func SolicitarCierreCargaGranelHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respuesta, err := Wscoem.SolicitarCierreCargaGranel(&post)
+	respuesta, err := Wscoem(r).SolicitarCierreCargaGranel(r.Context(), &post)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, respuesta, nil)
+	HttpResponseResult(w, r, respuesta)
 }
@@ -15,17 +15,23 @@ import (
 //	@Tags			Consultas de Comunicación de Embarque
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wscoemcons.ResultadoEjecucionOfDummyOutput
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/coemcons/Dummy [get]
 func DummyCoemconsHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wscoemcons.Dummy()
+	resultado, err := Wscoemcons(r).Dummy(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // ObtenerConsultaEstadosCOEMHandler godoc
@@ -36,11 +42,18 @@ func DummyCoemconsHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key				header		string	true	"API Key de acceso"
+//	@Param			x-cuit					header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict				header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			identificadorCabecera	query		string	true	"Identificador de la caratula"
 //	@Success		200						{object}	wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso
 //	@Failure		400						{object}	dto.ErrorResponse
 //	@Failure		401						{object}	dto.ErrorResponse
+//	@Failure		403						{object}	dto.ErrorResponse
+//	@Failure		422						{object}	dto.ErrorResponse
 //	@Failure		500						{object}	dto.ErrorResponse
+//	@Failure		502						{object}	dto.ErrorResponse
+//	@Failure		503						{object}	dto.ErrorResponse
+//	@Failure		504						{object}	dto.ErrorResponse
 //	@Router			/coemcons/ObtenerConsultaEstadosCOEM [get]
 func ObtenerConsultaEstadosCOEMHandler(w http.ResponseWriter, r *http.Request) {
 	identificadorCaratula := r.URL.Query().Get("identificadorCabecera")
@@ -50,13 +63,13 @@ func ObtenerConsultaEstadosCOEMHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoemcons.ObtenerConsultaEstadosCOEM(identificadorCaratula)
+	resultado, err := Wscoemcons(r).ObtenerConsultaEstadosCOEM(r.Context(), identificadorCaratula)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // ObtenerConsultaNoAbordoHandler godoc
@@ -67,11 +80,18 @@ func ObtenerConsultaEstadosCOEMHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key				header		string	true	"API Key de acceso"
+//	@Param			x-cuit					header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict				header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			identificadorCabecera	query		string	true	"Identificador de la caratula"
 //	@Success		200						{object}	wscoemcons.ResultadoEjecucionOfResultadoNoAbordoProceso
 //	@Failure		400						{object}	dto.ErrorResponse
 //	@Failure		401						{object}	dto.ErrorResponse
+//	@Failure		403						{object}	dto.ErrorResponse
+//	@Failure		422						{object}	dto.ErrorResponse
 //	@Failure		500						{object}	dto.ErrorResponse
+//	@Failure		502						{object}	dto.ErrorResponse
+//	@Failure		503						{object}	dto.ErrorResponse
+//	@Failure		504						{object}	dto.ErrorResponse
 //	@Router			/coemcons/ObtenerConsultaNoAbordo [get]
 func ObtenerConsultaNoAbordoHandler(w http.ResponseWriter, r *http.Request) {
 	identificadorCaratula := r.URL.Query().Get("identificadorCabecera")
@@ -81,13 +101,13 @@ func ObtenerConsultaNoAbordoHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoemcons.ObtenerConsultaNoAbordo(identificadorCaratula)
+	resultado, err := Wscoemcons(r).ObtenerConsultaNoAbordo(r.Context(), identificadorCaratula)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // ObtenerConsultaSolicitudesHandler godoc
@@ -98,11 +118,18 @@ func ObtenerConsultaNoAbordoHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key				header		string	true	"API Key de acceso"
+//	@Param			x-cuit					header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict				header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			identificadorCabecera	query		string	true	"Identificador de la caratula"
 //	@Success		200						{object}	wscoemcons.ResultadoEjecucionOfResultadoSolicitudProceso
 //	@Failure		400						{object}	dto.ErrorResponse
 //	@Failure		401						{object}	dto.ErrorResponse
+//	@Failure		403						{object}	dto.ErrorResponse
+//	@Failure		422						{object}	dto.ErrorResponse
 //	@Failure		500						{object}	dto.ErrorResponse
+//	@Failure		502						{object}	dto.ErrorResponse
+//	@Failure		503						{object}	dto.ErrorResponse
+//	@Failure		504						{object}	dto.ErrorResponse
 //	@Router			/coemcons/ObtenerConsultaSolicitudes [get]
 func ObtenerConsultaSolicitudesHandler(w http.ResponseWriter, r *http.Request) {
 	identificadorCaratula := r.URL.Query().Get("identificadorCabecera")
@@ -112,11 +139,11 @@ func ObtenerConsultaSolicitudesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wscoemcons.ObtenerConsultaSolicitudes(identificadorCaratula)
+	resultado, err := Wscoemcons(r).ObtenerConsultaSolicitudes(r.Context(), identificadorCaratula)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
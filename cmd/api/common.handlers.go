@@ -1,16 +1,24 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"slices"
+	"strconv"
 
 	"github.com/sehogas/goarca/internal/dto"
+	"github.com/sehogas/goarca/internal/middleware"
+	"github.com/sehogas/goarca/internal/services"
 	"github.com/sehogas/goarca/internal/util"
 )
 
 // InfoHandler godoc
 //
 //	@Summary		Muesta información de la API
-//	@Description	Muesta información de la API
+//	@Description	Muesta información de la API: versión, URL efectivas de los servicios de ARCA y estado de la renovación de tickets de los CUIT habilitados para la API Key
 //	@Tags			API
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
@@ -18,7 +26,137 @@ import (
 //	@Failure		401			{object}	dto.ErrorResponse
 //	@Router			/info [get]
 func InfoHandler(w http.ResponseWriter, r *http.Request) {
-	util.HttpResponseJSON(w, http.StatusOK, &dto.InfoResponse{
-		Version: Version,
-	}, nil)
+	info := &dto.InfoResponse{
+		Version:   Version,
+		Endpoints: services.ServiceURLs(Environment),
+	}
+	if Renewer != nil {
+		allowed := middleware.AllowedCuits(r)
+		for _, status := range Renewer.Status() {
+			if len(allowed) == 0 || slices.Contains(allowed, status.Cuit) {
+				info.Tickets = append(info.Tickets, dto.TicketRenewalStatus(status))
+			}
+		}
+	}
+	util.HttpResponseJSON(w, http.StatusOK, info, nil)
+}
+
+// CertificatesHandler godoc
+//
+//	@Summary		Estado de los certificados ARCA
+//	@Description	Informa la vigencia y los días restantes hasta el vencimiento del certificado de cada CUIT habilitado para la API Key
+//	@Tags			API
+//	@Produce		json
+//	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Success		200			{array}		dto.CertificateStatus
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Router			/certificates [get]
+func CertificatesHandler(w http.ResponseWriter, r *http.Request) {
+	allowed := middleware.AllowedCuits(r)
+	result := []dto.CertificateStatus{}
+	for _, status := range Certificates.Status() {
+		if len(allowed) == 0 || slices.Contains(allowed, status.Cuit) {
+			result = append(result, dto.CertificateStatus(status))
+		}
+	}
+	util.HttpResponseJSON(w, http.StatusOK, result, nil)
+}
+
+// HealthHandler godoc
+//
+//	@Summary		Estado de la API
+//	@Description	Informa el estado de los circuit breakers de los servicios de ARCA. No requiere API Key; responde 200 aunque algún servicio de ARCA no esté disponible (status degraded)
+//	@Tags			API
+//	@Produce		json
+//	@Success		200	{object}	dto.HealthResponse
+//	@Router			/health [get]
+func HealthHandler(w http.ResponseWriter, r *http.Request) {
+	health := &dto.HealthResponse{Status: "ok", Breakers: []dto.BreakerStatus{}}
+	for _, status := range services.Breakers() {
+		if status.State != services.BreakerClosed {
+			health.Status = "degraded"
+		}
+		health.Breakers = append(health.Breakers, dto.BreakerStatus(status))
+	}
+	util.HttpResponseJSON(w, http.StatusOK, health, nil)
+}
+
+// HttpResponseResult responde el resultado de una operación de ARCA. En modo estricto agrega a la respuesta
+// el arreglo warnings con los eventos y observaciones que no impidieron procesar la solicitud.
+func HttpResponseResult(w http.ResponseWriter, r *http.Request, result any) {
+	if !services.StrictFromContext(r.Context()) {
+		util.HttpResponseJSON(w, http.StatusOK, result, nil)
+		return
+	}
+
+	warnings := []dto.ErrorDetail{}
+	for _, e := range services.Warnings(result) {
+		warnings = append(warnings, dto.ErrorDetail{Code: e.Code, Message: e.Message})
+	}
+	response := map[string]json.RawMessage{}
+	data, err := json.Marshal(result)
+	if err == nil {
+		err = json.Unmarshal(data, &response)
+	}
+	if err != nil {
+		// El resultado no es un objeto JSON: se responde sin advertencias.
+		util.HttpResponseJSON(w, http.StatusOK, result, nil)
+		return
+	}
+	response["warnings"], _ = json.Marshal(warnings)
+	util.HttpResponseJSON(w, http.StatusOK, response, nil)
+}
+
+// statusClientClosedRequest es el código que se registra cuando el cliente cancela la solicitud (convención de nginx).
+const statusClientClosedRequest = 499
+
+// HttpResponseError responde el error devuelto por un servicio con el código HTTP que le corresponde:
+// 503 si ARCA no está disponible, 504 si no respondió a tiempo, 502 ante errores de comunicación o fallas SOAP,
+// 401 si WSAA rechazó la autenticación y 422 si ARCA rechazó la solicitud informando errores de negocio; en modo
+// estricto el 422 incluye la respuesta de ARCA, con los CAE de los comprobantes aprobados en una aprobación parcial.
+func HttpResponseError(w http.ResponseWriter, err error) {
+	var unavailable *services.TicketUnavailableError
+	var circuitOpen *services.CircuitOpenError
+	var timeout *services.TimeoutError
+	var wsaaErr *services.WsaaError
+	var arcaErr *services.ArcaError
+	var faultErr *services.SoapFaultError
+	var transportErr *services.TransportError
+
+	status := http.StatusInternalServerError
+	response := &dto.ErrorResponse{Error: err.Error(), Type: dto.ErrorInternal}
+	switch {
+	case errors.As(err, &unavailable):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(unavailable.RetryAfter.Seconds()))))
+		status, response.Type = http.StatusServiceUnavailable, dto.ErrorUnavailable
+	case errors.As(err, &circuitOpen):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(circuitOpen.RetryAfter.Seconds()))))
+		status, response.Type = http.StatusServiceUnavailable, dto.ErrorUnavailable
+	case errors.As(err, &timeout):
+		status, response.Type = http.StatusGatewayTimeout, dto.ErrorTimeout
+	case errors.Is(err, context.Canceled):
+		// El cliente cerró la conexión: no hay a quién responder.
+		util.HttpResponseJSON(w, statusClientClosedRequest, response, nil)
+		return
+	case errors.As(err, &wsaaErr):
+		status, response.Type, response.Code = http.StatusUnauthorized, dto.ErrorAuth, wsaaErr.Code
+		switch wsaaErr.Code {
+		case services.WsaaUnavailable, services.WsaaServiceUnavailable, services.WsaaInternalError:
+			status, response.Type = http.StatusServiceUnavailable, dto.ErrorUnavailable
+		}
+	case errors.As(err, &arcaErr):
+		status, response.Type = http.StatusUnprocessableEntity, dto.ErrorArca
+		for _, e := range arcaErr.Errors {
+			response.Errors = append(response.Errors, dto.ErrorDetail{Code: e.Code, Message: e.Message})
+		}
+		for _, e := range arcaErr.Warnings {
+			response.Warnings = append(response.Warnings, dto.ErrorDetail{Code: e.Code, Message: e.Message})
+		}
+		response.Result = arcaErr.Result
+	case errors.As(err, &faultErr):
+		status, response.Type, response.Code = http.StatusBadGateway, dto.ErrorSoapFault, faultErr.Code
+	case errors.As(err, &transportErr):
+		status, response.Type = http.StatusBadGateway, dto.ErrorTransport
+	}
+	util.HttpResponseJSON(w, status, response, err)
 }
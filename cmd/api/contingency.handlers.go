@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/sehogas/goarca/internal/dto"
+	"github.com/sehogas/goarca/internal/services"
+	"github.com/sehogas/goarca/internal/util"
+)
+
+// RequireContingency rechaza las solicitudes de contingencia si no se habilitó con CONTINGENCY=true.
+func RequireContingency(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if Contingency == nil {
+			err := errors.New("modo de contingencia no habilitado")
+			util.HttpResponseJSON(w, http.StatusForbidden, &dto.ErrorResponse{Error: err.Error()}, err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// ContingenciaEmitirHandler godoc
+//
+//	@Summary		Emitir comprobante en contingencia
+//	@Description	Numera el comprobante con el siguiente número del punto de venta y tipo, le asigna el CAEA vigente para su fecha y lo guarda pendiente de informar. Los comprobantes pendientes se informan con FECAEARegInformativo cuando el servicio de ARCA está disponible. El punto de venta debe estar habilitado para CAEA. La numeración continúa desde el último comprobante autorizado en ARCA, que se registra mientras el servicio está disponible.
+//	@Tags			Factura Electrónica - Contingencia
+//	@Accept			json
+//	@Produce		json
+//	@Param			x-api-key	header		string								true	"API Key de acceso"
+//	@Param			x-cuit		header		string								false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			request		body		dto.ContingenciaComprobanteRequest	true	"ContingenciaComprobanteRequest"
+//	@Success		200			{object}	services.ContingencyInvoice
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Router			/fe/contingencia/comprobantes [post]
+func ContingenciaEmitirHandler(w http.ResponseWriter, r *http.Request) {
+	var post dto.ContingenciaComprobanteRequest
+	err := json.NewDecoder(r.Body).Decode(&post)
+	if err != nil {
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: "error leyendo parámetros de la solicitud"}, err)
+		return
+	}
+	if post.Detalle == nil || post.Detalle.FEDetRequest == nil {
+		err := errors.New("falta el detalle del comprobante")
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	invoice, err := Contingency.Issue(r.Context(), tenant(r).Cuit, post.PtoVta, post.CbteTipo, post.Detalle)
+	if err != nil {
+		contingencyResponseError(w, err)
+		return
+	}
+	util.HttpResponseJSON(w, http.StatusOK, invoice, nil)
+}
+
+// ContingenciaComprobantesHandler godoc
+//
+//	@Summary		Comprobantes emitidos en contingencia
+//	@Description	Lista los comprobantes emitidos en contingencia con su estado: pendiente (de informar), informado o rechazado (con las observaciones de ARCA).
+//	@Tags			Factura Electrónica - Contingencia
+//	@Produce		json
+//	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			estado		query		string	false	"Filtra por estado: pendiente, informado o rechazado"
+//	@Success		200			{array}		services.ContingencyInvoice
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Router			/fe/contingencia/comprobantes [get]
+func ContingenciaComprobantesHandler(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("estado")
+	if status != "" && !slices.Contains([]string{services.ContingencyPending, services.ContingencyReported, services.ContingencyRejected}, status) {
+		err := errors.New("error leyendo parámetro estado")
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	invoices, err := Contingency.Invoices(r.Context(), tenant(r).Cuit, status)
+	if err != nil {
+		contingencyResponseError(w, err)
+		return
+	}
+	if invoices == nil {
+		invoices = []*services.ContingencyInvoice{}
+	}
+	util.HttpResponseJSON(w, http.StatusOK, invoices, nil)
+}
+
+// ContingenciaCorregirHandler godoc
+//
+//	@Summary		Corregir comprobante de contingencia rechazado
+//	@Description	Reemplaza el detalle de un comprobante rechazado por ARCA al informarlo por el corregido y lo vuelve a pendiente; se informa nuevamente en la verificación siguiente, que se adelanta. El comprobante conserva su número, fecha y CAEA. Solo se pueden corregir los comprobantes rechazados.
+//	@Tags			Factura Electrónica - Contingencia
+//	@Accept			json
+//	@Produce		json
+//	@Param			x-api-key	header		string								true	"API Key de acceso"
+//	@Param			x-cuit		header		string								false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			id			path		int									true	"Id del comprobante"
+//	@Param			request		body		dto.ContingenciaCorreccionRequest	true	"ContingenciaCorreccionRequest"
+//	@Success		200			{object}	services.ContingencyInvoice
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Router			/fe/contingencia/comprobantes/{id} [put]
+func ContingenciaCorregirHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: "error leyendo parámetro id"}, err)
+		return
+	}
+	var post dto.ContingenciaCorreccionRequest
+	err = json.NewDecoder(r.Body).Decode(&post)
+	if err != nil {
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: "error leyendo parámetros de la solicitud"}, err)
+		return
+	}
+	if post.Detalle == nil || post.Detalle.FEDetRequest == nil {
+		err := errors.New("falta el detalle del comprobante")
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	invoice, err := Contingency.Resubmit(r.Context(), tenant(r).Cuit, id, post.Detalle)
+	if err != nil {
+		contingencyResponseError(w, err)
+		return
+	}
+	util.HttpResponseJSON(w, http.StatusOK, invoice, nil)
+}
+
+// ContingenciaEstadoHandler godoc
+//
+//	@Summary		Estado de la contingencia
+//	@Description	Informa los puntos de venta habilitados para CAEA, los CAEA obtenidos, la cantidad de comprobantes pendientes de informar y rechazados, y el resultado de la última verificación.
+//	@Tags			Factura Electrónica - Contingencia
+//	@Produce		json
+//	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Success		200			{object}	services.ContingencyStatus
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Router			/fe/contingencia [get]
+func ContingenciaEstadoHandler(w http.ResponseWriter, r *http.Request) {
+	status, err := Contingency.Status(r.Context(), tenant(r).Cuit)
+	if err != nil {
+		contingencyResponseError(w, err)
+		return
+	}
+	util.HttpResponseJSON(w, http.StatusOK, status, nil)
+}
+
+// ContingenciaInformarHandler godoc
+//
+//	@Summary		Informar comprobantes de contingencia
+//	@Description	Adelanta la verificación periódica: obtiene los CAEA faltantes, informa los comprobantes pendientes y los puntos de venta sin movimiento. Se ejecuta en segundo plano; el resultado se consulta en /fe/contingencia.
+//	@Tags			Factura Electrónica - Contingencia
+//	@Produce		json
+//	@Param			x-api-key	header	string	true	"API Key de acceso"
+//	@Param			x-cuit		header	string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Success		202
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Router			/fe/contingencia/informar [post]
+func ContingenciaInformarHandler(w http.ResponseWriter, r *http.Request) {
+	Contingency.Trigger()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// contingencyResponseError responde los errores propios de la contingencia; el resto, con HttpResponseError.
+func contingencyResponseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrNoCAEA):
+		util.HttpResponseJSON(w, http.StatusConflict, &dto.ErrorResponse{Error: err.Error()}, err)
+	case errors.Is(err, services.ErrContingencyPtoVta):
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+	case errors.Is(err, services.ErrContingencyInvoiceNotFound):
+		util.HttpResponseJSON(w, http.StatusNotFound, &dto.ErrorResponse{Error: err.Error()}, err)
+	case errors.Is(err, services.ErrContingencyNotRejected):
+		util.HttpResponseJSON(w, http.StatusConflict, &dto.ErrorResponse{Error: err.Error()}, err)
+	case errors.Is(err, services.ErrContingencyNumbering):
+		util.HttpResponseJSON(w, http.StatusServiceUnavailable, &dto.ErrorResponse{Error: err.Error(), Type: dto.ErrorUnavailable}, err)
+	default:
+		HttpResponseError(w, err)
+	}
+}
@@ -19,42 +19,39 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/coem/AnularCOEM": {
-            "delete": {
-                "description": "Método a través del cual se anula una COEM. La COEM debe estar en estado en CURSO o REGISTRADA.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/tickets": {
+            "get": {
+                "description": "Lista el estado de los tickets de acceso de WSAA por servicio y CUIT, sin token ni firma. Requiere una API Key de administración.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comunicación de Embarque"
+                    "Administración"
                 ],
-                "summary": "Anular COEM",
+                "summary": "Tickets de acceso",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "API Key de acceso",
+                        "description": "API Key de administración",
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "AnularCOEMRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/wscoem.AnularCOEMRequest"
-                        }
+                        "type": "string",
+                        "description": "Filtra por CUIT",
+                        "name": "cuit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.AnularEmbarqueRta"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TicketInfo"
+                            }
                         }
                     },
                     "400": {
@@ -69,8 +66,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -78,52 +75,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/coem/AnularCaratula": {
+        "/admin/tickets/{cuit}/{service}": {
             "delete": {
-                "description": "Método que permite eliminar una carátula. Si se encuentran COEMs en estado Presentada o Autorizada se detiene la ejecución.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Descarta el ticket de acceso del servicio en memoria y en el almacenamiento; el próximo uso solicitará uno nuevo a WSAA. Requiere una API Key de administración.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comunicación de Embarque"
+                    "Administración"
                 ],
-                "summary": "Anular Carátula",
+                "summary": "Descartar ticket de acceso",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "API Key de acceso",
+                        "description": "API Key de administración",
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "AnularCaratulaRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/wscoem.AnularCaratulaRequest"
-                        }
+                        "type": "string",
+                        "description": "CUIT",
+                        "name": "cuit",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Servicio (wsfe, wgescomunicacionembarque, wconscomunicacionembarque, wGesTabRef)",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.AnularEmbarqueRta"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -137,52 +142,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/coem/CerrarCOEM": {
+        "/admin/tickets/{cuit}/{service}/renew": {
             "post": {
-                "description": "Método a través del cual se cierra la carga de una COEM asociada a una Carátula, permitiendo que el operador Aduanero pueda trabajar con ella. La COEM debe estar en estado CUR.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Solicita a WSAA un nuevo ticket de acceso para el servicio aunque el actual siga vigente, por ejemplo después de rotar el certificado. Si WSAA lo rechaza se conserva el ticket actual. Requiere una API Key de administración.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comunicación de Embarque"
+                    "Administración"
                 ],
-                "summary": "Cerrar COEM",
+                "summary": "Renovar ticket de acceso",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "API Key de acceso",
+                        "description": "API Key de administración",
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "CerrarCOEMRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/wscoem.CerrarCOEMRequest"
-                        }
+                        "type": "string",
+                        "description": "CUIT",
+                        "name": "cuit",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Servicio (wsfe, wgescomunicacionembarque, wconscomunicacionembarque, wGesTabRef)",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
+                            "$ref": "#/definitions/dto.TicketInfo"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -192,20 +205,26 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/Dummy": {
+        "/certificates": {
             "get": {
-                "description": "Visualizar el estado del servicio web, del servicio de autenticación y de la base de datos de ARCA",
+                "description": "Informa la vigencia y los días restantes hasta el vencimiento del certificado de cada CUIT habilitado para la API Key",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comunicación de Embarque"
+                    "API"
                 ],
-                "summary": "Muestra el estado del servicio",
+                "summary": "Estado de los certificados ARCA",
                 "parameters": [
                     {
                         "type": "string",
@@ -219,7 +238,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.ResultadoEjecucionDummy"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CertificateStatus"
+                            }
                         }
                     },
                     "401": {
@@ -227,19 +249,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/coem/RectificarCOEM": {
-            "put": {
-                "description": "Método a través del cual se modifican los valores de una COEM. La COEM debe estar en curso o registrada. No se habilita rectificar una COEM presentada o autorizada. No debe existir solicitud de cierre de carga iniciada o aprobada.",
+        "/coem/AnularCOEM": {
+            "delete": {
+                "description": "Método a través del cual se anula una COEM. La COEM debe estar en estado en CURSO o REGISTRADA.",
                 "consumes": [
                     "application/json"
                 ],
@@ -249,7 +265,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Rectificar COEM",
+                "summary": "Anular COEM",
                 "parameters": [
                     {
                         "type": "string",
@@ -259,12 +275,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "RectificarCOEMRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "AnularCOEMRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RectificarCOEMRequest"
+                            "$ref": "#/definitions/wscoem.AnularCOEMRequest"
                         }
                     }
                 ],
@@ -272,7 +300,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RectificarEmbarqueRta"
+                            "$ref": "#/definitions/wscoem.AnularEmbarqueRta"
                         }
                     },
                     "400": {
@@ -287,18 +315,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/RectificarCaratula": {
-            "put": {
-                "description": "Permite rectificar los datos de una Carátula previamente creada con el método RegistrarCaratula. Entre las restricciones, no se permitirá cargar datos idénticos a otra Carátula existente, ni modificar aquella carátula que tenga COEMs asociados.",
+        "/coem/AnularCaratula": {
+            "delete": {
+                "description": "Método que permite eliminar una carátula. Si se encuentran COEMs en estado Presentada o Autorizada se detiene la ejecución.",
                 "consumes": [
                     "application/json"
                 ],
@@ -308,7 +366,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Rectificar Carátula",
+                "summary": "Anular Carátula",
                 "parameters": [
                     {
                         "type": "string",
@@ -318,12 +376,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "RectificarCaratulaRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "AnularCaratulaRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RectificarCaratulaRequest"
+                            "$ref": "#/definitions/wscoem.AnularCaratulaRequest"
                         }
                     }
                 ],
@@ -331,7 +401,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RectificarEmbarqueRta"
+                            "$ref": "#/definitions/wscoem.AnularEmbarqueRta"
                         }
                     },
                     "400": {
@@ -346,18 +416,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/RegistrarCOEM": {
+        "/coem/CerrarCOEM": {
             "post": {
-                "description": "Método a través del cual se registran los valores de una COEM comprendidos en información de Contenedores con Carga, Contenedores Vacíos y Mercadería suelta, asociados a un Identificador de Carátula previamente creado con el método RegistrarCaratula.",
+                "description": "Método a través del cual se cierra la carga de una COEM asociada a una Carátula, permitiendo que el operador Aduanero pueda trabajar con ella. La COEM debe estar en estado CUR.",
                 "consumes": [
                     "application/json"
                 ],
@@ -367,7 +467,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Registrar COEM",
+                "summary": "Cerrar COEM",
                 "parameters": [
                     {
                         "type": "string",
@@ -377,12 +477,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "RegistrarCOEMRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "CerrarCOEMRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RegistrarCOEMRequest"
+                            "$ref": "#/definitions/wscoem.CerrarCOEMRequest"
                         }
                     }
                 ],
@@ -405,28 +517,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/RegistrarCaratula": {
-            "post": {
-                "description": "Crea el identificador necesario para inicializar el circuito de comunicación de embarque.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/coem/Dummy": {
+            "get": {
+                "description": "Visualizar el estado del servicio web, del servicio de autenticación y de la base de datos de ARCA",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Registrar Carátula",
+                "summary": "Muestra el estado del servicio",
                 "parameters": [
                     {
                         "type": "string",
@@ -436,30 +575,33 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "RegistrarCaratulaRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/wscoem.RegistrarCaratulaRequest"
-                        }
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessageResponse"
+                            "$ref": "#/definitions/wscoem.ResultadoEjecucionDummy"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -469,62 +611,21 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/coem/SolicitarAnulacionCOEM": {
-            "post": {
-                "description": "Método a través del cual se solicita la anulación de una COEM cuando ya se encuentra en estado PRESENTADA o AUTORIZADA, caso contrario puede utilizar el método AnularCOEM.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Comunicación de Embarque"
-                ],
-                "summary": "Solicitar Anulación COEM",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "API Key de acceso",
-                        "name": "x-api-key",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "SolicitarAnulacionCOEMRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/wscoem.SolicitarAnulacionCOEMRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "502": {
+                        "description": "Bad Gateway",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -532,9 +633,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/coem/SolicitarCambioBuque": {
+        "/coem/RectificarCOEM": {
             "put": {
-                "description": "Método a través del cual se modificarán el Identificador y/o nombre del buque. Deben existir COEMs presentadas o autorizadas, caso contrario aún se puede enviar la rectificación de la carátula. No debe existir solicitud de cierre de carga iniciada o aprobada.",
+                "description": "Método a través del cual se modifican los valores de una COEM. La COEM debe estar en curso o registrada. No se habilita rectificar una COEM presentada o autorizada. No debe existir solicitud de cierre de carga iniciada o aprobada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -544,7 +645,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Solicitar cambio de Buque",
+                "summary": "Rectificar COEM",
                 "parameters": [
                     {
                         "type": "string",
@@ -554,12 +655,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "SolicitarCambioBuqueRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "RectificarCOEMRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.SolicitarCambioBuqueRequest"
+                            "$ref": "#/definitions/wscoem.RectificarCOEMRequest"
                         }
                     }
                 ],
@@ -567,7 +680,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
+                            "$ref": "#/definitions/wscoem.RectificarEmbarqueRta"
                         }
                     },
                     "400": {
@@ -582,18 +695,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/SolicitarCambioFechas": {
+        "/coem/RectificarCaratula": {
             "put": {
-                "description": "Método a través del cual se modifican las Fechas de Arribo y/o Fecha de Zarpada de la Carátula. Deben existir COEMs presentadas o autorizadas, caso contrario aún se puede enviar la rectificación de la carátula. No debe existir solicitud de cierre de carga iniciada o aprobada.",
+                "description": "Permite rectificar los datos de una Carátula previamente creada con el método RegistrarCaratula. Entre las restricciones, no se permitirá cargar datos idénticos a otra Carátula existente, ni modificar aquella carátula que tenga COEMs asociados.",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,7 +746,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Solicitar cambio de Fechas",
+                "summary": "Rectificar Carátula",
                 "parameters": [
                     {
                         "type": "string",
@@ -613,12 +756,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "SolicitarCambioFechasRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "RectificarCaratulaRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.SolicitarCambioFechasRequest"
+                            "$ref": "#/definitions/wscoem.RectificarCaratulaRequest"
                         }
                     }
                 ],
@@ -626,7 +781,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
+                            "$ref": "#/definitions/wscoem.RectificarEmbarqueRta"
                         }
                     },
                     "400": {
@@ -641,18 +796,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/SolicitarCambioLOT": {
-            "put": {
-                "description": "Método a través del cual se modifica el Lugar Operativo de la Carátula. Deben existir COEMs presentadas o autorizadas, caso contrario aún se puede enviar la rectificación de la carátula. No debe existir solicitud de cierre de carga iniciada o aprobada.",
+        "/coem/RegistrarCOEM": {
+            "post": {
+                "description": "Método a través del cual se registran los valores de una COEM comprendidos en información de Contenedores con Carga, Contenedores Vacíos y Mercadería suelta, asociados a un Identificador de Carátula previamente creado con el método RegistrarCaratula.",
                 "consumes": [
                     "application/json"
                 ],
@@ -662,7 +847,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Solicitar cambio de LOT",
+                "summary": "Registrar COEM",
                 "parameters": [
                     {
                         "type": "string",
@@ -672,12 +857,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "SolicitarCambioLOTRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "RegistrarCOEMRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.SolicitarCambioLOTRequest"
+                            "$ref": "#/definitions/wscoem.RegistrarCOEMRequest"
                         }
                     }
                 ],
@@ -700,18 +897,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/SolicitarCierreCargaContoBulto": {
+        "/coem/RegistrarCaratula": {
             "post": {
-                "description": "Método a través del cual se solicita el cierre de carga de una Carátula que contiene COMEs cuyos permisos de embarque amparan contenedores o bultos sueltos (no Granel). Todas las COEMs deben estar en estado AUTORIZADA o ANULADA. No se consideran las marcadas como NO ABORDO. No debe existir solicitud de cierre de carga contenedores o bultos sueltos iniciada o aprobada.",
+                "description": "Crea el identificador necesario para inicializar el circuito de comunicación de embarque.",
                 "consumes": [
                     "application/json"
                 ],
@@ -721,7 +948,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Solicitar Cierre de Carga Contenedores y/o Bultos",
+                "summary": "Registrar Carátula",
                 "parameters": [
                     {
                         "type": "string",
@@ -731,12 +958,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "SolicitarCierreCargaContoBultoRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "RegistrarCaratulaRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.SolicitarCierreCargaContoBultoRequest"
+                            "$ref": "#/definitions/wscoem.RegistrarCaratulaRequest"
                         }
                     }
                 ],
@@ -744,7 +983,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
+                            "$ref": "#/definitions/dto.MessageResponse"
                         }
                     },
                     "400": {
@@ -759,18 +998,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/SolicitarCierreCargaGranel": {
+        "/coem/SolicitarAnulacionCOEM": {
             "post": {
-                "description": "Método a través del cual se solicita el cierre de carga de una Carátula que contiene COEMs cuyos permisos de embarque contienen Mercadería a granel. Todas las COEMs deben estar en estado AUTORIZADA o ANULADA. No se consideran las líneas marcadas como NO ABORDO. Requiere detalle de todas las COMEs, Permisos de Embarque e ítems abordo. No debe existir solicitud de cierre de carga granel iniciada o aprobada.",
+                "description": "Método a través del cual se solicita la anulación de una COEM cuando ya se encuentra en estado PRESENTADA o AUTORIZADA, caso contrario puede utilizar el método AnularCOEM.",
                 "consumes": [
                     "application/json"
                 ],
@@ -780,7 +1049,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Solicitar Cierre de Carga Granel",
+                "summary": "Solicitar Anulación COEM",
                 "parameters": [
                     {
                         "type": "string",
@@ -790,12 +1059,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "SolicitarCierreCargaGranelRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "SolicitarAnulacionCOEMRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.SolicitarCierreCargaGranelRequest"
+                            "$ref": "#/definitions/wscoem.SolicitarAnulacionCOEMRequest"
                         }
                     }
                 ],
@@ -818,18 +1099,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coem/SolicitarNoABordo": {
-            "post": {
-                "description": "Método a través del cual se solicita no abordar una o varias líneas de mercadería o contenedores asociados a una carátula/COEM. La COEM debe estar en estado PRESENTADA o AUTORIZADA. No debe existir solicitud de cierre de carga iniciada o aprobada.",
+        "/coem/SolicitarCambioBuque": {
+            "put": {
+                "description": "Método a través del cual se modificarán el Identificador y/o nombre del buque. Deben existir COEMs presentadas o autorizadas, caso contrario aún se puede enviar la rectificación de la carátula. No debe existir solicitud de cierre de carga iniciada o aprobada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -839,7 +1150,7 @@ const docTemplate = `{
                 "tags": [
                     "Comunicación de Embarque"
                 ],
-                "summary": "Solicitar No Abordo",
+                "summary": "Solicitar cambio de Buque",
                 "parameters": [
                     {
                         "type": "string",
@@ -849,12 +1160,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "SolicitarNoABordoRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "SolicitarCambioBuqueRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/wscoem.SolicitarNoABordoRequest"
+                            "$ref": "#/definitions/wscoem.SolicitarCambioBuqueRequest"
                         }
                     }
                 ],
@@ -877,25 +1200,58 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coemcons/Dummy": {
-            "get": {
-                "description": "Visualizar el estado del servicio web, del servicio de autenticación y de la base de datos de ARCA",
+        "/coem/SolicitarCambioFechas": {
+            "put": {
+                "description": "Método a través del cual se modifican las Fechas de Arribo y/o Fecha de Zarpada de la Carátula. Deben existir COEMs presentadas o autorizadas, caso contrario aún se puede enviar la rectificación de la carátula. No debe existir solicitud de cierre de carga iniciada o aprobada.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Consultas de Comunicación de Embarque"
+                    "Comunicación de Embarque"
                 ],
-                "summary": "Muestra el estado del servicio",
+                "summary": "Solicitar cambio de Fechas",
                 "parameters": [
                     {
                         "type": "string",
@@ -903,13 +1259,40 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "SolicitarCambioFechasRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wscoem.SolicitarCambioFechasRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfDummyOutput"
+                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -918,18 +1301,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coemcons/ObtenerConsultaEstadosCOEM": {
-            "get": {
-                "description": "Obtiene una lista de comunicaciones de embarque comprendidas en la cabecera informada.",
+        "/coem/SolicitarCambioLOT": {
+            "put": {
+                "description": "Método a través del cual se modifica el Lugar Operativo de la Carátula. Deben existir COEMs presentadas o autorizadas, caso contrario aún se puede enviar la rectificación de la carátula. No debe existir solicitud de cierre de carga iniciada o aprobada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -937,9 +1350,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Consultas de Comunicación de Embarque"
+                    "Comunicación de Embarque"
                 ],
-                "summary": "Obtener Consulta Estados COEM",
+                "summary": "Solicitar cambio de LOT",
                 "parameters": [
                     {
                         "type": "string",
@@ -950,17 +1363,31 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Identificador de la caratula",
-                        "name": "identificadorCabecera",
-                        "in": "query",
-                        "required": true
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "SolicitarCambioLOTRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wscoem.SolicitarCambioLOTRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso"
+                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
                         }
                     },
                     "400": {
@@ -975,18 +1402,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coemcons/ObtenerConsultaNoAbordo": {
-            "get": {
-                "description": "Obtiene una lista de aquellas comunicaciones de embarque relacionadas con la carátula de referencia para las cuales se ha definido el no abordaje parcial o total de su contenido, el cual puede tratarse de bultos sueltos, mercadería a granel, contenedores de carga o cont¢nedores vacíos.",
+        "/coem/SolicitarCierreCargaContoBulto": {
+            "post": {
+                "description": "Método a través del cual se solicita el cierre de carga de una Carátula que contiene COMEs cuyos permisos de embarque amparan contenedores o bultos sueltos (no Granel). Todas las COEMs deben estar en estado AUTORIZADA o ANULADA. No se consideran las marcadas como NO ABORDO. No debe existir solicitud de cierre de carga contenedores o bultos sueltos iniciada o aprobada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -994,9 +1451,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Consultas de Comunicación de Embarque"
+                    "Comunicación de Embarque"
                 ],
-                "summary": "Obtener Consulta No Abordo",
+                "summary": "Solicitar Cierre de Carga Contenedores y/o Bultos",
                 "parameters": [
                     {
                         "type": "string",
@@ -1007,17 +1464,31 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Identificador de la caratula",
-                        "name": "identificadorCabecera",
-                        "in": "query",
-                        "required": true
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "SolicitarCierreCargaContoBultoRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wscoem.SolicitarCierreCargaContoBultoRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfResultadoNoAbordoProceso"
+                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
                         }
                     },
                     "400": {
@@ -1032,18 +1503,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/coemcons/ObtenerConsultaSolicitudes": {
-            "get": {
-                "description": "Obtiene la lista de solicitudes de comunicaciones asociadas a la cabecera informada. Número y tipo de solicitud, estado y fecha.",
+        "/coem/SolicitarCierreCargaGranel": {
+            "post": {
+                "description": "Método a través del cual se solicita el cierre de carga de una Carátula que contiene COEMs cuyos permisos de embarque contienen Mercadería a granel. Todas las COEMs deben estar en estado AUTORIZADA o ANULADA. No se consideran las líneas marcadas como NO ABORDO. Requiere detalle de todas las COMEs, Permisos de Embarque e ítems abordo. No debe existir solicitud de cierre de carga granel iniciada o aprobada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1051,9 +1552,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Consultas de Comunicación de Embarque"
+                    "Comunicación de Embarque"
                 ],
-                "summary": "Obtener Consulta de Solicitudes",
+                "summary": "Solicitar Cierre de Carga Granel",
                 "parameters": [
                     {
                         "type": "string",
@@ -1064,17 +1565,31 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Identificador de la caratula",
-                        "name": "identificadorCabecera",
-                        "in": "query",
-                        "required": true
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "SolicitarCierreCargaGranelRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wscoem.SolicitarCierreCargaGranelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfResultadoSolicitudProceso"
+                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
                         }
                     },
                     "400": {
@@ -1089,25 +1604,58 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FECAEARegInformativo": {
+        "/coem/SolicitarNoABordo": {
             "post": {
-                "description": "Este método permite informar para cada CAEA otorgado, la totalidad de los comprobantes emitidos y asociados a cada CAEA",
+                "description": "Método a través del cual se solicita no abordar una o varias líneas de mercadería o contenedores asociados a una carátula/COEM. La COEM debe estar en estado PRESENTADA o AUTORIZADA. No debe existir solicitud de cierre de carga iniciada o aprobada.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Comunicación de Embarque"
                 ],
-                "summary": "Informar comprobantes emitidos y asociados a una CAEA",
+                "summary": "Solicitar No Abordo",
                 "parameters": [
                     {
                         "type": "string",
@@ -1117,12 +1665,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "FeCAEARegInfReqRequest",
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "SolicitarNoABordoRequest",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.FeCAEARegInfReqRequest"
+                            "$ref": "#/definitions/wscoem.SolicitarNoABordoRequest"
                         }
                     }
                 ],
@@ -1130,7 +1690,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FECAEAResponse"
+                            "$ref": "#/definitions/wscoem.RegistrarEmbarqueRta"
                         }
                     },
                     "400": {
@@ -1145,25 +1705,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FECAEASinMovimientoConsultar": {
+        "/coemcons/Dummy": {
             "get": {
-                "description": "Esta operación permite consultar mediante un CAEA, cuáles fueron los puntos de venta que fueron notificados como sin movimiento. El cliente envía el requerimiento, el cual es atendido por el WS, superadas las validaciones de seguridad se informa el CAEA, puntos de venta identificados como sin movimientos y fecha de proceso. En caso de informar el punto de venta, se informan los datos vinculados a ese punto de venta en particular.",
+                "description": "Visualizar el estado del servicio web, del servicio de autenticación y de la base de datos de ARCA",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Consultas de Comunicación de Embarque"
                 ],
-                "summary": "Consulta de Puntos Venta sin movimientos",
+                "summary": "Muestra el estado del servicio",
                 "parameters": [
                     {
                         "type": "string",
@@ -1174,24 +1764,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "CAEA",
-                        "name": "CAEA",
-                        "in": "query",
-                        "required": true
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "Punto de Venta",
-                        "name": "PtoVta",
-                        "in": "query",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FECotizacionResponse"
+                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfDummyOutput"
                         }
                     },
                     "401": {
@@ -1200,25 +1788,52 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FECAESolicitar": {
-            "post": {
-                "description": "Solicitar CAE",
+        "/coemcons/ObtenerConsultaEstadosCOEM": {
+            "get": {
+                "description": "Obtiene una lista de comunicaciones de embarque comprendidas en la cabecera informada.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Consultas de Comunicación de Embarque"
                 ],
-                "summary": "Solicitar CAE",
+                "summary": "Obtener Consulta Estados COEM",
                 "parameters": [
                     {
                         "type": "string",
@@ -1228,20 +1843,30 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "FECAESolicitarRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.FECAESolicitarRequest"
-                        }
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identificador de la caratula",
+                        "name": "identificadorCabecera",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FECAEResponse"
+                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso"
                         }
                     },
                     "400": {
@@ -1256,25 +1881,58 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FECompConsultar": {
+        "/coemcons/ObtenerConsultaNoAbordo": {
             "get": {
-                "description": "Esta operación permite consultar mediante tipo, numero de comprobante y punto de venta los datos de un comprobante ya emitido. Dentro de los datos del comprobante resultante se obtiene el tipo de emisión utilizado para generar el código de autorización.",
+                "description": "Obtiene una lista de aquellas comunicaciones de embarque relacionadas con la carátula de referencia para las cuales se ha definido el no abordaje parcial o total de su contenido, el cual puede tratarse de bultos sueltos, mercadería a granel, contenedores de carga o cont¢nedores vacíos.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Consultas de Comunicación de Embarque"
                 ],
-                "summary": "Consulta datos de un Comprobante",
+                "summary": "Obtener Consulta No Abordo",
                 "parameters": [
                     {
                         "type": "string",
@@ -1285,22 +1943,20 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Punto de Venta",
-                        "name": "PtoVta",
-                        "in": "query",
-                        "required": true
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "Tipo de Comprobante",
-                        "name": "CbteTipo",
-                        "in": "query",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "Número de Comprobante",
-                        "name": "CbteNro",
+                        "description": "Identificador de la caratula",
+                        "name": "identificadorCabecera",
                         "in": "query",
                         "required": true
                     }
@@ -1309,7 +1965,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FECompConsultaResponse"
+                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfResultadoNoAbordoProceso"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1318,25 +1980,58 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FECompTotXRequest": {
+        "/coemcons/ObtenerConsultaSolicitudes": {
             "get": {
-                "description": "Retorna la cantidad máxima de registros que se podrá incluir en un request al método FECAESolicitar / FECAEARegInformativo.",
+                "description": "Obtiene la lista de solicitudes de comunicaciones asociadas a la cabecera informada. Número y tipo de solicitud, estado y fecha.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Consultas de Comunicación de Embarque"
                 ],
-                "summary": "Cantidad máxima de registros por request",
+                "summary": "Obtener Consulta de Solicitudes",
                 "parameters": [
                     {
                         "type": "string",
@@ -1344,13 +2039,38 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identificador de la caratula",
+                        "name": "identificadorCabecera",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FERegXReqResponse"
+                            "$ref": "#/definitions/wscoemcons.ResultadoEjecucionOfResultadoSolicitudProceso"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1359,25 +2079,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FECompUltimoAutorizado": {
+        "/fe/FECAEAConsultar": {
             "get": {
-                "description": "Consultar último comprobante autorizado",
+                "description": "Este método permite consultar la información correspondiente a un CAEA previamente otorgado para un período/orden.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Último comprobante autorizado",
+                "summary": "Consultar CAEA",
                 "parameters": [
                     {
                         "type": "string",
@@ -1388,15 +2138,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Punto de venta",
-                        "name": "ptoVta",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Año y mes de la quincena (aaaamm)",
+                        "name": "Periodo",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Tipo de comprobante",
-                        "name": "cbteTipo",
+                        "description": "Quincena: 1 (del 1 al 15) o 2 (del 16 a fin de mes)",
+                        "name": "Orden",
                         "in": "query",
                         "required": true
                     }
@@ -1405,11 +2167,1231 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FERecuperaLastCbteResponse"
+                            "$ref": "#/definitions/wsfe.FECAEAGetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECAEARegInformativo": {
+            "post": {
+                "description": "Este método permite informar para cada CAEA otorgado, la totalidad de los comprobantes emitidos y asociados a cada CAEA",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Informar comprobantes emitidos y asociados a una CAEA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "FeCAEARegInfReqRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FeCAEARegInfReqRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FECAEAResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECAEASinMovimientoConsultar": {
+            "get": {
+                "description": "Esta operación permite consultar mediante un CAEA, cuáles fueron los puntos de venta que fueron notificados como sin movimiento. El cliente envía el requerimiento, el cual es atendido por el WS, superadas las validaciones de seguridad se informa el CAEA, puntos de venta identificados como sin movimientos y fecha de proceso. En caso de informar el punto de venta, se informan los datos vinculados a ese punto de venta en particular.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Consulta de Puntos Venta sin movimientos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CAEA",
+                        "name": "CAEA",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Punto de Venta",
+                        "name": "PtoVta",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FECAEASinMovConsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECAEASinMovimientoInformar": {
+            "post": {
+                "description": "Esta operación permite informar que un CAEA otorgado no registró comprobantes asociados en un punto de venta. Debe informarse hasta la fecha tope de información del CAEA (FchTopeInf).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Informar CAEA sin movimiento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "FECAEASinMovimientoInformarRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FECAEASinMovimientoInformarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FECAEASinMovResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECAEASolicitar": {
+            "post": {
+                "description": "Este método permite solicitar un CAEA para la quincena indicada. Puede solicitarse dentro de los 5 días corridos anteriores al comienzo de cada quincena. Si el CAEA ya fue otorgado, se obtiene con FECAEAConsultar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Solicitar CAEA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "FECAEASolicitarRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FECAEASolicitarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FECAEAGetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECAESolicitar": {
+            "post": {
+                "description": "Solicitar CAE",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Solicitar CAE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "description": "FECAESolicitarRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FECAESolicitarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FECAEResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECompConsultar": {
+            "get": {
+                "description": "Esta operación permite consultar mediante tipo, numero de comprobante y punto de venta los datos de un comprobante ya emitido. Dentro de los datos del comprobante resultante se obtiene el tipo de emisión utilizado para generar el código de autorización.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Consulta datos de un Comprobante",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Punto de Venta",
+                        "name": "PtoVta",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de Comprobante",
+                        "name": "CbteTipo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Número de Comprobante",
+                        "name": "CbteNro",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FECompConsultaResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECompTotXRequest": {
+            "get": {
+                "description": "Retorna la cantidad máxima de registros que se podrá incluir en un request al método FECAESolicitar / FECAEARegInformativo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Cantidad máxima de registros por request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FERegXReqResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FECompUltimoAutorizado": {
+            "get": {
+                "description": "Consultar último comprobante autorizado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Último comprobante autorizado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Punto de venta",
+                        "name": "ptoVta",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo de comprobante",
+                        "name": "cbteTipo",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FERecuperaLastCbteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FEDummy": {
+            "get": {
+                "description": "Visualizar el estado del servicio web, del servicio de autenticación y de la base de datos de ARCA",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Estado del servicio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.DummyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FEParamGetActividades": {
+            "get": {
+                "description": "Esta operación permite consultar los códigos de actividades, sus descripciones y el orden (si es actividad primaria, secundaria, etc)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Consulta Actividades",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FEActividadesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FEParamGetCondicionIvaReceptor": {
+            "get": {
+                "description": "Esta operación permite consultar los identificadores de la condicion frente al IVA del receptor, su descripción y a la clase de comprobante que corresponde.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Consulta condiciones IVA del receptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clase de Comprobate",
+                        "name": "ClaseCmp",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.CondicionIvaReceptorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FEParamGetCotizacion": {
+            "get": {
+                "description": "Retorna la última cotización de la base de datos aduanera de la moneda ingresada. Este valor es orientativo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Cotización de moneda",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "código de moneda",
+                        "name": "monId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "fecha de cotización",
+                        "name": "fchCotiz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FECotizacionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fe/FEParamGetPtosVenta": {
+            "get": {
+                "description": "Este método permite consultar los puntos de venta para ambos tipos de Código de Autorización (CAE y CAEA) gestionados previamente por la CUIT emisora.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Factura Electrónica"
+                ],
+                "summary": "Puntos de Venta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key de acceso",
+                        "name": "x-api-key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wsfe.FEPtoVentaResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1419,20 +3401,38 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEDummy": {
+        "/fe/FEParamGetTiposCbte": {
             "get": {
-                "description": "Visualizar el estado del servicio web, del servicio de autenticación y de la base de datos de ARCA",
+                "description": "Este método permite consultar los tipos de comprobantes habilitados en este WS.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Estado del servicio",
+                "summary": "Tipos de Comprobante",
                 "parameters": [
                     {
                         "type": "string",
@@ -1440,13 +3440,25 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.DummyResponse"
+                            "$ref": "#/definitions/wsfe.CbteTipoResponse"
                         }
                     },
                     "401": {
@@ -1455,25 +3467,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetActividades": {
+        "/fe/FEParamGetTiposConcepto": {
             "get": {
-                "description": "Esta operación permite consultar los códigos de actividades, sus descripciones y el orden (si es actividad primaria, secundaria, etc)",
+                "description": "Este método devuelve los tipos de conceptos posibles en este WS.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Consulta Actividades",
+                "summary": "Tipos de Concepto",
                 "parameters": [
                     {
                         "type": "string",
@@ -1481,13 +3523,25 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FEActividadesResponse"
+                            "$ref": "#/definitions/wsfe.ConceptoTipoResponse"
                         }
                     },
                     "401": {
@@ -1496,25 +3550,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetCondicionIvaReceptor": {
+        "/fe/FEParamGetTiposDoc": {
             "get": {
-                "description": "Esta operación permite consultar los identificadores de la condicion frente al IVA del receptor, su descripción y a la clase de comprobante que corresponde.",
+                "description": "Este método retorna el universo de tipos de documentos disponibles en el presente WS.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Consulta condiciones IVA del receptor",
+                "summary": "Tipos de Documento",
                 "parameters": [
                     {
                         "type": "string",
@@ -1525,17 +3609,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Clase de Comprobate",
-                        "name": "ClaseCmp",
-                        "in": "query",
-                        "required": true
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.CondicionIvaReceptorResponse"
+                            "$ref": "#/definitions/wsfe.DocTipoResponse"
                         }
                     },
                     "401": {
@@ -1544,25 +3633,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetCotizacion": {
+        "/fe/FEParamGetTiposIva": {
             "get": {
-                "description": "Retorna la última cotización de la base de datos aduanera de la moneda ingresada. Este valor es orientativo.",
+                "description": "Mediante este método se obtiene la totalidad de alícuotas de IVA posibles de uso en el presente WS, detallando código y descripción.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Cotización de moneda",
+                "summary": "Tipos de IVA",
                 "parameters": [
                     {
                         "type": "string",
@@ -1573,23 +3692,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "código de moneda",
-                        "name": "monId",
-                        "in": "query",
-                        "required": true
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "fecha de cotización",
-                        "name": "fchCotiz",
-                        "in": "query"
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FECotizacionResponse"
+                            "$ref": "#/definitions/wsfe.IvaTipoResponse"
                         }
                     },
                     "401": {
@@ -1598,25 +3716,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetPtosVenta": {
+        "/fe/FEParamGetTiposMonedas": {
             "get": {
-                "description": "Este método permite consultar los puntos de venta para ambos tipos de Código de Autorización (CAE y CAEA) gestionados previamente por la CUIT emisora.",
+                "description": "Este método retorna el universo de Monedas disponibles en el presente WS, indicando id y descripción de cada una.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Puntos de Venta",
+                "summary": "Tipos de Monedas",
                 "parameters": [
                     {
                         "type": "string",
@@ -1624,13 +3772,25 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FEPtoVentaResponse"
+                            "$ref": "#/definitions/wsfe.MonedaResponse"
                         }
                     },
                     "401": {
@@ -1639,25 +3799,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetTiposCbte": {
+        "/fe/FEParamGetTiposOpcional": {
             "get": {
-                "description": "Este método permite consultar los tipos de comprobantes habilitados en este WS.",
+                "description": "Este método permite consultar los códigos y descripciones de los tipos de datos Opcionales que se encuentran habilitados para ser usados en el WS",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Tipos de Comprobante",
+                "summary": "Tipos Opcional",
                 "parameters": [
                     {
                         "type": "string",
@@ -1665,13 +3855,25 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.CbteTipoResponse"
+                            "$ref": "#/definitions/wsfe.OpcionalTipoResponse"
                         }
                     },
                     "401": {
@@ -1680,25 +3882,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetTiposConcepto": {
+        "/fe/FEParamGetTiposPaises": {
             "get": {
-                "description": "Este método devuelve los tipos de conceptos posibles en este WS.",
+                "description": "Esta operación permite consultar los códigos de países y descripción de los mismos.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Tipos de Concepto",
+                "summary": "Tipos de Países",
                 "parameters": [
                     {
                         "type": "string",
@@ -1706,13 +3938,25 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.ConceptoTipoResponse"
+                            "$ref": "#/definitions/wsfe.FEPaisResponse"
                         }
                     },
                     "401": {
@@ -1721,25 +3965,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetTiposDoc": {
+        "/fe/FEParamGetTiposTributos": {
             "get": {
-                "description": "Este método retorna el universo de tipos de documentos disponibles en el presente WS.",
+                "description": "Devuelve los posibles códigos de tributos que puede contener un comprobante y su descripción.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Factura Electrónica"
                 ],
-                "summary": "Tipos de Documento",
+                "summary": "Tipos Tributos",
                 "parameters": [
                     {
                         "type": "string",
@@ -1747,13 +4021,25 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.DocTipoResponse"
+                            "$ref": "#/definitions/wsfe.FETributoResponse"
                         }
                     },
                     "401": {
@@ -1762,25 +4048,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetTiposIva": {
+        "/fe/contingencia": {
             "get": {
-                "description": "Mediante este método se obtiene la totalidad de alícuotas de IVA posibles de uso en el presente WS, detallando código y descripción.",
+                "description": "Informa los puntos de venta habilitados para CAEA, los CAEA obtenidos, la cantidad de comprobantes pendientes de informar y rechazados, y el resultado de la última verificación.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Factura Electrónica - Contingencia"
                 ],
-                "summary": "Tipos de IVA",
+                "summary": "Estado de la contingencia",
                 "parameters": [
                     {
                         "type": "string",
@@ -1788,13 +4104,19 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.IvaTipoResponse"
+                            "$ref": "#/definitions/services.ContingencyStatus"
                         }
                     },
                     "401": {
@@ -1803,6 +4125,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1812,16 +4140,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/fe/FEParamGetTiposMonedas": {
+        "/fe/contingencia/comprobantes": {
             "get": {
-                "description": "Este método retorna el universo de Monedas disponibles en el presente WS, indicando id y descripción de cada una.",
+                "description": "Lista los comprobantes emitidos en contingencia con su estado: pendiente (de informar), informado o rechazado (con las observaciones de ARCA).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Factura Electrónica - Contingencia"
                 ],
-                "summary": "Tipos de Monedas",
+                "summary": "Comprobantes emitidos en contingencia",
                 "parameters": [
                     {
                         "type": "string",
@@ -1829,13 +4157,34 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra por estado: pendiente, informado o rechazado",
+                        "name": "estado",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.MonedaResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.ContingencyInvoice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1844,6 +4193,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1851,18 +4206,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/fe/FEParamGetTiposOpcional": {
-            "get": {
-                "description": "Este método permite consultar los códigos y descripciones de los tipos de datos Opcionales que se encuentran habilitados para ser usados en el WS",
+            },
+            "post": {
+                "description": "Numera el comprobante con el siguiente número del punto de venta y tipo, le asigna el CAEA vigente para su fecha y lo guarda pendiente de informar. Los comprobantes pendientes se informan con FECAEARegInformativo cuando el servicio de ARCA está disponible. El punto de venta debe estar habilitado para CAEA. La numeración continúa desde el último comprobante autorizado en ARCA, que se registra mientras el servicio está disponible.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Factura Electrónica - Contingencia"
                 ],
-                "summary": "Tipos Opcional",
+                "summary": "Emitir comprobante en contingencia",
                 "parameters": [
                     {
                         "type": "string",
@@ -1870,13 +4226,34 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "description": "ContingenciaComprobanteRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContingenciaComprobanteRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.OpcionalTipoResponse"
+                            "$ref": "#/definitions/services.ContingencyInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1885,25 +4262,46 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/fe/FEParamGetTiposPaises": {
-            "get": {
-                "description": "Esta operación permite consultar los códigos de países y descripción de los mismos.",
+        "/fe/contingencia/comprobantes/{id}": {
+            "put": {
+                "description": "Reemplaza el detalle de un comprobante rechazado por ARCA al informarlo por el corregido y lo vuelve a pendiente; se informa nuevamente en la verificación siguiente, que se adelanta. El comprobante conserva su número, fecha y CAEA. Solo se pueden corregir los comprobantes rechazados.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Factura Electrónica - Contingencia"
                 ],
-                "summary": "Tipos de Países",
+                "summary": "Corregir comprobante de contingencia rechazado",
                 "parameters": [
                     {
                         "type": "string",
@@ -1911,13 +4309,41 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id del comprobante",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ContingenciaCorreccionRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContingenciaCorreccionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wsfe.FEPaisResponse"
+                            "$ref": "#/definitions/services.ContingencyInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1926,6 +4352,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1935,16 +4379,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/fe/FEParamGetTiposTributos": {
-            "get": {
-                "description": "Devuelve los posibles códigos de tributos que puede contener un comprobante y su descripción.",
+        "/fe/contingencia/informar": {
+            "post": {
+                "description": "Adelanta la verificación periódica: obtiene los CAEA faltantes, informa los comprobantes pendientes y los puntos de venta sin movimiento. Se ejecuta en segundo plano; el resultado se consulta en /fe/contingencia.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Factura Electrónica"
+                    "Factura Electrónica - Contingencia"
                 ],
-                "summary": "Tipos Tributos",
+                "summary": "Informar comprobantes de contingencia",
                 "parameters": [
                     {
                         "type": "string",
@@ -1952,14 +4396,17 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/wsfe.FETributoResponse"
-                        }
+                    "202": {
+                        "description": "Accepted"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -1967,8 +4414,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1997,6 +4444,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2024,11 +4483,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2050,6 +4539,18 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2065,11 +4566,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2095,6 +4620,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2122,11 +4659,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2152,6 +4719,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2179,11 +4758,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2209,6 +4818,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2236,11 +4857,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2266,6 +4917,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2293,11 +4956,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2323,6 +5016,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2350,11 +5055,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2380,6 +5115,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2407,11 +5154,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2437,6 +5214,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2464,11 +5253,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2493,6 +5312,18 @@ const docTemplate = `{
                         "name": "x-api-key",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2514,11 +5345,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2544,6 +5405,18 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "CUIT con el que se opera (requerido si la API Key habilita más de uno)",
+                        "name": "x-cuit",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)",
+                        "name": "x-strict",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "ID de referencia",
@@ -2571,18 +5444,68 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Informa el estado de los circuit breakers de los servicios de ARCA. No requiere API Key; responde 200 aunque algún servicio de ARCA no esté disponible (status degraded)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "Estado de la API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
                     }
                 }
             }
         },
         "/info": {
             "get": {
-                "description": "Muesta información de la API",
+                "description": "Muesta información de la API: versión, URL efectivas de los servicios de ARCA y estado de la renovación de tickets de los CUIT habilitados para la API Key",
                 "produces": [
                     "application/json"
                 ],
@@ -2614,14 +5537,188 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        }
+    },
+    "definitions": {
+        "dto.BreakerStatus": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "openedAt": {
+                    "type": "string"
+                },
+                "retryAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "closed",
+                        "open",
+                        "half-open"
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CertificateStatus": {
+            "type": "object",
+            "properties": {
+                "checkedAt": {
+                    "type": "string"
+                },
+                "cuit": {
+                    "type": "integer"
+                },
+                "daysLeft": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "notAfter": {
+                    "type": "string"
+                },
+                "notBefore": {
+                    "type": "string"
+                },
+                "serialNumber": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "valid",
+                        "warning",
+                        "expired",
+                        "error"
+                    ]
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContingenciaComprobanteRequest": {
+            "type": "object",
+            "properties": {
+                "CbteTipo": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "Detalle": {
+                    "description": "Detalle es el comprobante sin número ni CAEA, que se asignan al emitirlo. Si CbteFch está vacía se\nutiliza la fecha actual.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/wsfe.FECAEADetRequest"
+                        }
+                    ]
+                },
+                "PtoVta": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.ContingenciaCorreccionRequest": {
+            "type": "object",
+            "properties": {
+                "Detalle": {
+                    "description": "Detalle es el comprobante corregido. El número, la fecha y el CAEA se conservan los del comprobante rechazado.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/wsfe.FECAEADetRequest"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.ErrorResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "error": {
                     "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ErrorDetail"
+                    }
+                },
+                "result": {
+                    "description": "Result es la respuesta de ARCA, informada en modo estricto: en una aprobación parcial contiene los CAE otorgados.",
+                    "type": "object"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "internal",
+                        "unavailable",
+                        "timeout",
+                        "transport",
+                        "soap_fault",
+                        "auth",
+                        "arca"
+                    ]
+                },
+                "warnings": {
+                    "description": "Warnings son los eventos y observaciones de la respuesta de ARCA, informados en modo estricto.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ErrorDetail"
+                    }
+                }
+            }
+        },
+        "dto.FECAEASinMovimientoInformarRequest": {
+            "type": "object",
+            "properties": {
+                "CAEA": {
+                    "type": "string",
+                    "example": "36423456789012"
+                },
+                "PtoVta": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.FECAEASolicitarRequest": {
+            "type": "object",
+            "properties": {
+                "Orden": {
+                    "description": "Orden es la quincena: 1 (del 1 al 15) o 2 (del 16 a fin de mes).",
+                    "type": "integer",
+                    "example": 1
+                },
+                "Periodo": {
+                    "description": "Periodo es el año y mes (aaaamm) de la quincena.",
+                    "type": "integer",
+                    "example": 202610
                 }
             }
         },
@@ -2653,9 +5750,39 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "breakers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BreakerStatus"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "degraded"
+                    ]
+                }
+            }
+        },
         "dto.InfoResponse": {
             "type": "object",
             "properties": {
+                "endpoints": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TicketRenewalStatus"
+                    }
+                },
                 "version": {
                     "type": "string"
                 }
@@ -2669,6 +5796,191 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TicketInfo": {
+            "type": "object",
+            "properties": {
+                "blockedUntil": {
+                    "type": "string"
+                },
+                "cuit": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expirationTime": {
+                    "type": "string"
+                },
+                "generationTime": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "memory",
+                        "file",
+                        "sql",
+                        "redis",
+                        "none"
+                    ]
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.TicketRenewalStatus": {
+            "type": "object",
+            "properties": {
+                "cuit": {
+                    "type": "integer"
+                },
+                "expirationTime": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastRenewal": {
+                    "type": "string"
+                },
+                "nextAttempt": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ArcaMessage": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CAEA": {
+            "type": "object",
+            "properties": {
+                "caea": {
+                    "type": "string"
+                },
+                "cuit": {
+                    "type": "integer"
+                },
+                "fchProceso": {
+                    "type": "string"
+                },
+                "fchTopeInf": {
+                    "type": "string"
+                },
+                "fchVigDesde": {
+                    "type": "string"
+                },
+                "fchVigHasta": {
+                    "type": "string"
+                },
+                "orden": {
+                    "type": "integer"
+                },
+                "periodo": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ContingencyInvoice": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "caea": {
+                    "type": "string"
+                },
+                "cbteFch": {
+                    "type": "string"
+                },
+                "cbteNro": {
+                    "type": "integer"
+                },
+                "cbteTipo": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "cuit": {
+                    "type": "integer"
+                },
+                "detalle": {
+                    "$ref": "#/definitions/wsfe.FECAEADetRequest"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "observaciones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ArcaMessage"
+                    }
+                },
+                "ptoVta": {
+                    "type": "integer"
+                },
+                "reported": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ContingencyStatus": {
+            "type": "object",
+            "properties": {
+                "caeas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CAEA"
+                    }
+                },
+                "cuit": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastRun": {
+                    "type": "string"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "ptosVta": {
+                    "description": "PtosVta son los puntos de venta habilitados para CAEA según ARCA (nil si aún no se pudieron consultar).",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rejected": {
+                    "type": "integer"
+                }
+            }
+        },
         "wgestabref.ArrayOfDatoComplementario": {
             "type": "object",
             "properties": {
@@ -4126,6 +7438,17 @@ const docTemplate = `{
                 }
             }
         },
+        "wsfe.ArrayOfFECAEASinMov": {
+            "type": "object",
+            "properties": {
+                "FECAEASinMov": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/wsfe.FECAEASinMov"
+                    }
+                }
+            }
+        },
         "wsfe.ArrayOfFECAEDetResponse": {
             "type": "object",
             "properties": {
@@ -4602,6 +7925,49 @@ const docTemplate = `{
                 }
             }
         },
+        "wsfe.FECAEAGet": {
+            "type": "object",
+            "properties": {
+                "CAEA": {
+                    "type": "string"
+                },
+                "FchProceso": {
+                    "type": "string"
+                },
+                "FchTopeInf": {
+                    "type": "string"
+                },
+                "FchVigDesde": {
+                    "type": "string"
+                },
+                "FchVigHasta": {
+                    "type": "string"
+                },
+                "Observaciones": {
+                    "$ref": "#/definitions/wsfe.ArrayOfObs"
+                },
+                "Orden": {
+                    "type": "integer"
+                },
+                "Periodo": {
+                    "type": "integer"
+                }
+            }
+        },
+        "wsfe.FECAEAGetResponse": {
+            "type": "object",
+            "properties": {
+                "Errors": {
+                    "$ref": "#/definitions/wsfe.ArrayOfErr"
+                },
+                "Events": {
+                    "$ref": "#/definitions/wsfe.ArrayOfEvt"
+                },
+                "ResultGet": {
+                    "$ref": "#/definitions/wsfe.FECAEAGet"
+                }
+            }
+        },
         "wsfe.FECAEAResponse": {
             "type": "object",
             "properties": {
@@ -4619,6 +7985,57 @@ const docTemplate = `{
                 }
             }
         },
+        "wsfe.FECAEASinMov": {
+            "type": "object",
+            "properties": {
+                "CAEA": {
+                    "type": "string"
+                },
+                "FchProceso": {
+                    "type": "string"
+                },
+                "PtoVta": {
+                    "type": "integer"
+                }
+            }
+        },
+        "wsfe.FECAEASinMovConsResponse": {
+            "type": "object",
+            "properties": {
+                "Errors": {
+                    "$ref": "#/definitions/wsfe.ArrayOfErr"
+                },
+                "Events": {
+                    "$ref": "#/definitions/wsfe.ArrayOfEvt"
+                },
+                "ResultGet": {
+                    "$ref": "#/definitions/wsfe.ArrayOfFECAEASinMov"
+                }
+            }
+        },
+        "wsfe.FECAEASinMovResponse": {
+            "type": "object",
+            "properties": {
+                "CAEA": {
+                    "type": "string"
+                },
+                "Errors": {
+                    "$ref": "#/definitions/wsfe.ArrayOfErr"
+                },
+                "Events": {
+                    "$ref": "#/definitions/wsfe.ArrayOfEvt"
+                },
+                "FchProceso": {
+                    "type": "string"
+                },
+                "PtoVta": {
+                    "type": "integer"
+                },
+                "Resultado": {
+                    "type": "string"
+                }
+            }
+        },
         "wsfe.FECAECabResponse": {
             "type": "object",
             "properties": {
@@ -15,17 +15,23 @@ import (
 //	@Tags			Consulta de Tablas de Referencia
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wgestabref.WsDummyResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/gestabref/Dummy [get]
 func DummyGesTabRefHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsgestabref.Dummy()
+	resultado, err := Wsgestabref(r).Dummy(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // ConsultarFechaUltActHandler godoc
@@ -36,11 +42,18 @@ func DummyGesTabRefHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.FechaUltAct
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ConsultarFechaUltAct [get]
 func ConsultarFechaUltActHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -50,13 +63,13 @@ func ConsultarFechaUltActHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wsgestabref.ConsultarFechaUltAct(idReferencia)
+	resultado, err := Wsgestabref(r).ConsultarFechaUltAct(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // ListaArancelHandler godoc
@@ -67,11 +80,18 @@ func ConsultarFechaUltActHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.Opciones
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaArancel [get]
 func ListaArancelHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -81,13 +101,13 @@ func ListaArancelHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wsgestabref.ListaArancel(idReferencia)
+	resultado, err := Wsgestabref(r).ListaArancel(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // ListaDescripcionHandler godoc
@@ -98,11 +118,18 @@ func ListaArancelHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.Descripciones
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaDescripcion [get]
 func ListaDescripcionHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -112,13 +139,13 @@ func ListaDescripcionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := Wsgestabref.ListaDescripcion(idReferencia)
+	data, err := Wsgestabref(r).ListaDescripcion(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
 
 // ListaDescripcionDecodificacionHandler godoc
@@ -129,11 +156,18 @@ func ListaDescripcionHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.DescripcionesCodificaciones
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaDescripcionDecodificacion [get]
 func ListaDescripcionDecodificacionHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -143,13 +177,13 @@ func ListaDescripcionDecodificacionHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	data, err := Wsgestabref.ListaDescripcionDecodificacion(idReferencia)
+	data, err := Wsgestabref(r).ListaDescripcionDecodificacion(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
 
 // ListaEmpresasHandler godoc
@@ -160,11 +194,18 @@ func ListaDescripcionDecodificacionHandler(w http.ResponseWriter, r *http.Reques
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.Empresas
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaEmpresas [get]
 func ListaEmpresasHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -174,13 +215,13 @@ func ListaEmpresasHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := Wsgestabref.ListaEmpresas(idReferencia)
+	data, err := Wsgestabref(r).ListaEmpresas(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
 
 // ListaLugaresOperativosHandler godoc
@@ -191,11 +232,18 @@ func ListaEmpresasHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.LugaresOperativos
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaLugaresOperativos [get]
 func ListaLugaresOperativosHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -205,13 +253,13 @@ func ListaLugaresOperativosHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := Wsgestabref.ListaLugaresOperativos(idReferencia)
+	data, err := Wsgestabref(r).ListaLugaresOperativos(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
 
 // ListaPaisesAduanasHandler godoc
@@ -222,11 +270,18 @@ func ListaLugaresOperativosHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.PaisesAduanas
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaPaisesAduanas [get]
 func ListaPaisesAduanasHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -236,13 +291,13 @@ func ListaPaisesAduanasHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := Wsgestabref.ListaPaisesAduanas(idReferencia)
+	data, err := Wsgestabref(r).ListaPaisesAduanas(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
 
 // ListaTablasReferenciaHandler godoc
@@ -253,19 +308,26 @@ func ListaPaisesAduanasHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wgestabref.TablasReferencia
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaTablasReferencia [get]
 func ListaTablasReferenciaHandler(w http.ResponseWriter, r *http.Request) {
-	data, err := Wsgestabref.ListaTablasReferencia()
+	data, err := Wsgestabref(r).ListaTablasReferencia(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
 
 // ListaVigenciasHandler godoc
@@ -276,11 +338,18 @@ func ListaTablasReferenciaHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.Vigencias
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaVigencias [get]
 func ListaVigenciasHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -290,13 +359,13 @@ func ListaVigenciasHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := Wsgestabref.ListaVigencias(idReferencia)
+	data, err := Wsgestabref(r).ListaVigencias(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
 
 // ListaDatoComplementarioHandler godoc
@@ -307,11 +376,18 @@ func ListaVigenciasHandler(w http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			x-api-key		header		string	true	"API Key de acceso"
+//	@Param			x-cuit			header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict		header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			IdReferencia	query		string	true	"ID de referencia"
 //	@Success		200				{object}	wgestabref.DatosComplementarios
 //	@Failure		400				{object}	dto.ErrorResponse
 //	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
 //	@Failure		500				{object}	dto.ErrorResponse
+//	@Failure		502				{object}	dto.ErrorResponse
+//	@Failure		503				{object}	dto.ErrorResponse
+//	@Failure		504				{object}	dto.ErrorResponse
 //	@Router			/gestabref/ListaDatoComplementario [get]
 func ListaDatoComplementarioHandler(w http.ResponseWriter, r *http.Request) {
 	idReferencia := r.URL.Query().Get("IdReferencia")
@@ -321,11 +397,11 @@ func ListaDatoComplementarioHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := Wsgestabref.ListaDatoComplementario(idReferencia)
+	data, err := Wsgestabref(r).ListaDatoComplementario(r.Context(), idReferencia)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
 
-	util.HttpResponseJSON(w, http.StatusOK, data, nil)
+	HttpResponseResult(w, r, data)
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
@@ -23,10 +25,15 @@ import (
 var (
 	Version string = "development"
 
-	Wscoem      *services.Wscoem
-	Wscoemcons  *services.Wscoemcons
-	Wsgestabref *services.Wsgestabref
-	Wsfe        *services.Wsfe
+	Environment services.Environment
+
+	Tenants map[int64]*Tenant
+
+	Renewer *services.TicketRenewer
+
+	Certificates *services.CertificateMonitor
+
+	Contingency *services.ContingencyManager
 )
 
 //	@title			API proxy a los webservices de ARCA
@@ -64,25 +71,244 @@ func main() {
 		saveXML = (environment == services.TESTING)
 	}
 
+	Environment = environment
+
 	logger.Debug("Mode", "PRODUCTION", (environment == services.PRODUCTION), "Log XML", printXML, "Save XML", saveXML)
 
-	cuit, err := strconv.ParseInt(os.Getenv("CUIT"), 10, 64)
-	if err != nil {
-		logger.Error("missing or invalid environment variable CUIT")
+	tlsOptions := services.TLSOptions{
+		CAFiles: util.SplitList(os.Getenv("ARCA_CA_FILE")),
+		Pins: map[services.Environment][]string{
+			services.TESTING:    util.SplitList(os.Getenv("ARCA_TLS_PINS_TESTING")),
+			services.PRODUCTION: util.SplitList(os.Getenv("ARCA_TLS_PINS_PRODUCTION")),
+		},
+	}
+	tlsOptions.Insecure, _ = strconv.ParseBool(os.Getenv("ARCA_TLS_INSECURE"))
+	if tlsOptions.Insecure {
+		logger.Error("ATENCIÓN: ARCA_TLS_INSECURE=true, no se verifican los certificados TLS de los servidores de ARCA")
+	}
+	if err := services.SetTLSOptions(tlsOptions); err != nil {
+		logger.Error("SetTLSOptions()", "err", err.Error())
 		os.Exit(1)
 	}
-	logger.Debug("", "CUIT", cuit)
 
-	if os.Getenv("PRIVATE_KEY_FILE") == "" {
-		logger.Error("missing environment variable PRIVATE_KEY_FILE")
+	serviceURLs := make(map[string]string)
+	for service, variable := range map[string]string{
+		services.EndpointWSAA:       "ARCA_URL_WSAA",
+		services.EndpointWSFE:       "ARCA_URL_WSFE",
+		services.EndpointWSCOEM:     "ARCA_URL_WSCOEM",
+		services.EndpointWSCOEMCons: "ARCA_URL_WSCOEMCONS",
+		services.EndpointWGESTABREF: "ARCA_URL_WGESTABREF",
+	} {
+		if value := strings.TrimSpace(os.Getenv(variable)); value != "" {
+			serviceURLs[service] = value
+		}
+	}
+	if err := services.SetServiceURLs(environment, serviceURLs); err != nil {
+		logger.Error("SetServiceURLs()", "err", err.Error())
+		os.Exit(1)
+	}
+	for service, url := range serviceURLs {
+		logger.Info("URL de servicio de ARCA configurada", "service", service, "url", url)
+	}
+
+	transportOptions := services.DefaultTransportOptions
+	transportOptions.ProxyURL = strings.TrimSpace(os.Getenv("ARCA_PROXY_URL"))
+	transportOptions.NoProxy = util.SplitList(os.Getenv("ARCA_NO_PROXY"))
+	if transportOptions.ProxyURL != "" && len(transportOptions.NoProxy) == 0 {
+		transportOptions.NoProxy = util.SplitList(os.Getenv("NO_PROXY"))
+	}
+	for variable, value := range map[string]*time.Duration{
+		"ARCA_DIAL_TIMEOUT":          &transportOptions.DialTimeout,
+		"ARCA_TLS_HANDSHAKE_TIMEOUT": &transportOptions.TLSHandshakeTimeout,
+		"ARCA_IDLE_CONN_TIMEOUT":     &transportOptions.IdleConnTimeout,
+	} {
+		if os.Getenv(variable) != "" {
+			*value, err = time.ParseDuration(os.Getenv(variable))
+			if err != nil || *value <= 0 {
+				logger.Error("environment variable " + variable + " invalid.")
+				os.Exit(1)
+			}
+		}
+	}
+	for variable, value := range map[string]*int{
+		"ARCA_MAX_CONNS_PER_HOST":      &transportOptions.MaxConnsPerHost,
+		"ARCA_MAX_IDLE_CONNS_PER_HOST": &transportOptions.MaxIdleConnsPerHost,
+	} {
+		if os.Getenv(variable) != "" {
+			*value, err = strconv.Atoi(os.Getenv(variable))
+			if err != nil || *value < 0 {
+				logger.Error("environment variable " + variable + " invalid.")
+				os.Exit(1)
+			}
+		}
+	}
+	if err := services.SetTransportOptions(transportOptions); err != nil {
+		logger.Error("SetTransportOptions()", "err", err.Error())
+		os.Exit(1)
+	}
+
+	defaultTimeout := services.DefaultOperationTimeout
+	if os.Getenv("ARCA_TIMEOUT") != "" {
+		defaultTimeout, err = time.ParseDuration(os.Getenv("ARCA_TIMEOUT"))
+		if err != nil || defaultTimeout <= 0 {
+			logger.Error("environment variable ARCA_TIMEOUT invalid.")
+			os.Exit(1)
+		}
+	}
+	timeouts := make(map[string]time.Duration)
+	for _, value := range util.SplitList(os.Getenv("ARCA_TIMEOUTS")) {
+		operation, duration, _ := strings.Cut(value, "=")
+		timeout, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil || timeout <= 0 {
+			logger.Error("environment variable ARCA_TIMEOUTS invalid.", "value", value)
+			os.Exit(1)
+		}
+		timeouts[strings.TrimSpace(operation)] = timeout
+	}
+	services.SetOperationTimeouts(defaultTimeout, timeouts)
+
+	retryPolicy := services.DefaultRetryPolicy
+	if os.Getenv("ARCA_RETRIES") != "" {
+		retryPolicy.MaxRetries, err = strconv.Atoi(os.Getenv("ARCA_RETRIES"))
+		if err != nil || retryPolicy.MaxRetries < 0 {
+			logger.Error("environment variable ARCA_RETRIES invalid.")
+			os.Exit(1)
+		}
+	}
+	for variable, value := range map[string]*time.Duration{
+		"ARCA_RETRY_BACKOFF":     &retryPolicy.BaseDelay,
+		"ARCA_RETRY_MAX_BACKOFF": &retryPolicy.MaxDelay,
+	} {
+		if os.Getenv(variable) != "" {
+			*value, err = time.ParseDuration(os.Getenv(variable))
+			if err != nil || *value < 0 {
+				logger.Error("environment variable " + variable + " invalid.")
+				os.Exit(1)
+			}
+		}
+	}
+	retryPolicy.Operations = make(map[string]int)
+	for _, value := range util.SplitList(os.Getenv("ARCA_RETRIES_BY_OPERATION")) {
+		operation, count, _ := strings.Cut(value, "=")
+		retries, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || retries < 0 {
+			logger.Error("environment variable ARCA_RETRIES_BY_OPERATION invalid.", "value", value)
+			os.Exit(1)
+		}
+		retryPolicy.Operations[strings.TrimSpace(operation)] = retries
+	}
+	services.SetRetryPolicy(retryPolicy)
+
+	breakerPolicy := services.DefaultBreakerPolicy
+	if os.Getenv("ARCA_BREAKER_FAILURES") != "" {
+		breakerPolicy.FailureThreshold, err = strconv.Atoi(os.Getenv("ARCA_BREAKER_FAILURES"))
+		if err != nil || breakerPolicy.FailureThreshold < 0 {
+			logger.Error("environment variable ARCA_BREAKER_FAILURES invalid.")
+			os.Exit(1)
+		}
+	}
+	if os.Getenv("ARCA_BREAKER_OPEN_TIMEOUT") != "" {
+		breakerPolicy.OpenTimeout, err = time.ParseDuration(os.Getenv("ARCA_BREAKER_OPEN_TIMEOUT"))
+		if err != nil || breakerPolicy.OpenTimeout <= 0 {
+			logger.Error("environment variable ARCA_BREAKER_OPEN_TIMEOUT invalid.")
+			os.Exit(1)
+		}
+	}
+	services.SetBreakerPolicy(breakerPolicy)
+
+	redactionRules, err := services.ParseRedactionRules(util.SplitList(os.Getenv("AUDIT_REDACT")))
+	if err != nil {
+		logger.Error("environment variable AUDIT_REDACT invalid.", "err", err.Error())
 		os.Exit(1)
 	}
+	if err := services.SetRedactionRules(redactionRules); err != nil {
+		logger.Error("SetRedactionRules()", "err", err.Error())
+		os.Exit(1)
+	}
+	services.SetWsaaCapture(logger, printXML, saveXML)
+
+	if saveXML {
+		auditSink, err := services.NewAuditSink(os.Getenv("AUDIT_SINK"), os.Getenv("AUDIT_DSN"))
+		if err != nil {
+			logger.Error("NewAuditSink()", "err", err.Error())
+			os.Exit(1)
+		}
+		var auditOptions services.AuditOptions
+		auditOptions.Compress, _ = strconv.ParseBool(os.Getenv("AUDIT_COMPRESS"))
+		for variable, value := range map[string]*time.Duration{
+			"AUDIT_MAX_AGE":            &auditOptions.MaxAge,
+			"AUDIT_RETENTION_INTERVAL": &auditOptions.RetentionInterval,
+		} {
+			if os.Getenv(variable) != "" {
+				*value, err = time.ParseDuration(os.Getenv(variable))
+				if err != nil || *value < 0 {
+					logger.Error("environment variable " + variable + " invalid.")
+					os.Exit(1)
+				}
+			}
+		}
+		if os.Getenv("AUDIT_MAX_SIZE_MB") != "" {
+			maxSize, err := strconv.ParseInt(os.Getenv("AUDIT_MAX_SIZE_MB"), 10, 64)
+			if err != nil || maxSize < 0 {
+				logger.Error("environment variable AUDIT_MAX_SIZE_MB invalid.")
+				os.Exit(1)
+			}
+			auditOptions.MaxSize = maxSize << 20
+		}
+		if raw, _ := strconv.ParseBool(os.Getenv("AUDIT_RAW")); raw {
+			keyText, err := util.ReadPassphrase(os.Getenv("AUDIT_RAW_KEY_FILE"), "AUDIT_RAW_KEY")
+			if err == nil && len(keyText) == 0 {
+				err = errors.New("AUDIT_RAW=true requiere AUDIT_RAW_KEY_FILE o AUDIT_RAW_KEY")
+			}
+			if err == nil {
+				auditOptions.RawKey, err = services.ParseAuditKey(keyText)
+			}
+			if err != nil {
+				logger.Error("AUDIT_RAW", "err", err.Error())
+				os.Exit(1)
+			}
+			logger.Warn("Se guardan copias cifradas sin ocultar de los intercambios con ARCA en raw/")
+		}
+		services.SetAuditSink(auditSink, auditOptions)
+		defer services.CloseAudit()
+	}
 
-	if os.Getenv("CERTIFICATE_FILE") == "" {
-		logger.Error("missing environment variable CERTIFICATE_FILE")
+	var registry *services.TenantRegistry
+	if os.Getenv("TENANTS_FILE") != "" {
+		registry, err = services.LoadTenants(os.Getenv("TENANTS_FILE"))
+	} else {
+		var tenant *services.Tenant
+		tenant, err = services.TenantFromEnv()
+		if err == nil {
+			registry, err = services.NewTenantRegistry(tenant)
+		}
+	}
+	if err != nil {
+		logger.Error("tenants", "err", err.Error())
 		os.Exit(1)
 	}
 
+	if os.Getenv("ARCHIVE_DIR") != "" {
+		var archiveOptions services.ArchiveOptions
+		archiveOptions.Sign, _ = strconv.ParseBool(os.Getenv("ARCHIVE_SIGN"))
+		keyText, err := util.ReadPassphrase(os.Getenv("ARCHIVE_KEY_FILE"), "ARCHIVE_KEY")
+		if err == nil && len(keyText) > 0 {
+			archiveOptions.Key, err = services.ParseAuditKey(keyText)
+		}
+		if err != nil {
+			logger.Error("ARCHIVE_KEY", "err", err.Error())
+			os.Exit(1)
+		}
+		archive, err := services.NewArchive(os.Getenv("ARCHIVE_DIR"), registry.All(), archiveOptions)
+		if err != nil {
+			logger.Error("NewArchive()", "err", err.Error())
+			os.Exit(1)
+		}
+		services.AddCaptureHook(archive.Record)
+		defer archive.Close()
+		logger.Info("Archivo fiscal de intercambios con ARCA", "dir", os.Getenv("ARCHIVE_DIR"), "sign", archiveOptions.Sign, "encrypted", len(archiveOptions.Key) > 0)
+	}
+
 	if len(os.Getenv("KEYS_FILE")) == 0 {
 		logger.Error("missing environment variable KEYS_FILE")
 		os.Exit(1)
@@ -97,28 +323,116 @@ func main() {
 		}
 	}
 
-	Wscoem, err = services.NewWscoem(logger, environment, cuit, printXML, saveXML)
+	ticketStore, err := services.NewTicketStore(os.Getenv("TICKET_STORE"), os.Getenv("TICKET_STORE_DSN"))
 	if err != nil {
-		logger.Error("NewWscoem()", "err", err.Error())
+		logger.Error("NewTicketStore()", "err", err.Error())
 		os.Exit(1)
 	}
+	services.SetTicketStore(ticketStore)
 
-	Wscoemcons, err = services.NewWscoemcons(logger, environment, cuit, printXML, saveXML)
-	if err != nil {
-		logger.Error("NewWscoemcons()", "err", err.Error())
-		os.Exit(1)
+	if os.Getenv("TA_ALREADY_AUTH_WAIT") != "" {
+		wait, err := time.ParseDuration(os.Getenv("TA_ALREADY_AUTH_WAIT"))
+		if err != nil || wait < 0 {
+			logger.Error("environment variable TA_ALREADY_AUTH_WAIT invalid.")
+			os.Exit(1)
+		}
+		services.SetAlreadyAuthenticatedWait(wait)
 	}
 
-	Wsgestabref, err = services.Newgestabref(logger, environment, cuit, printXML, saveXML)
-	if err != nil {
-		logger.Error("Newgestabref()", "err", err.Error())
-		os.Exit(1)
+	Tenants = make(map[int64]*Tenant)
+	for _, t := range registry.All() {
+		Tenants[t.Cuit], err = NewTenant(logger, environment, t, printXML, saveXML)
+		if err != nil {
+			logger.Error("NewTenant()", "CUIT", t.Cuit, "err", err.Error())
+			os.Exit(1)
+		}
+		logger.Debug("", "CUIT", t.Cuit)
 	}
 
-	Wsfe, err = services.NewWsfe(logger, environment, cuit, printXML, saveXML)
-	if err != nil {
-		logger.Error("NewWsfe()", "err", err.Error())
-		os.Exit(1)
+	warnDays := []int{30, 15, 7}
+	if os.Getenv("CERT_WARN_DAYS") != "" {
+		warnDays = nil
+		for _, value := range strings.Split(os.Getenv("CERT_WARN_DAYS"), ",") {
+			days, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || days <= 0 {
+				logger.Error("environment variable CERT_WARN_DAYS invalid.")
+				os.Exit(1)
+			}
+			warnDays = append(warnDays, days)
+		}
+	}
+	Certificates = services.NewCertificateMonitor(logger, registry.All(), warnDays)
+	Certificates.Start(context.Background())
+
+	renew := true
+	if os.Getenv("TA_RENEW") != "" {
+		renew, err = strconv.ParseBool(os.Getenv("TA_RENEW"))
+		if err != nil {
+			logger.Error("environment variable TA_RENEW invalid.")
+			os.Exit(1)
+		}
+	}
+	if renew {
+		margin := 30 * time.Minute
+		if os.Getenv("TA_RENEW_MARGIN") != "" {
+			margin, err = time.ParseDuration(os.Getenv("TA_RENEW_MARGIN"))
+			if err != nil || margin <= 0 {
+				logger.Error("environment variable TA_RENEW_MARGIN invalid.")
+				os.Exit(1)
+			}
+		}
+		jitter := 5 * time.Minute
+		if os.Getenv("TA_RENEW_JITTER") != "" {
+			jitter, err = time.ParseDuration(os.Getenv("TA_RENEW_JITTER"))
+			if err != nil || jitter < 0 {
+				logger.Error("environment variable TA_RENEW_JITTER invalid.")
+				os.Exit(1)
+			}
+		}
+		var targets []services.RenewalTarget
+		for _, t := range registry.All() {
+			targets = append(targets, Tenants[t.Cuit].RenewalTargets()...)
+		}
+		Renewer = services.NewTicketRenewer(logger, environment, targets, margin, jitter)
+		Renewer.Start(context.Background())
+		logger.Debug("Renovación de tickets en segundo plano", "margin", margin, "jitter", jitter)
+	}
+
+	if contingency, _ := strconv.ParseBool(os.Getenv("CONTINGENCY")); contingency {
+		fileName := os.Getenv("CONTINGENCY_DB")
+		if fileName == "" {
+			fileName = "data/contingency.db"
+		}
+		interval := 15 * time.Minute
+		if os.Getenv("CONTINGENCY_INTERVAL") != "" {
+			interval, err = time.ParseDuration(os.Getenv("CONTINGENCY_INTERVAL"))
+			if err != nil || interval <= 0 {
+				logger.Error("environment variable CONTINGENCY_INTERVAL invalid.")
+				os.Exit(1)
+			}
+		}
+		var cbteTipos []int32
+		for _, value := range util.SplitList(os.Getenv("CONTINGENCY_CBTE_TIPOS")) {
+			cbteTipo, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || cbteTipo <= 0 {
+				logger.Error("environment variable CONTINGENCY_CBTE_TIPOS invalid.")
+				os.Exit(1)
+			}
+			cbteTipos = append(cbteTipos, int32(cbteTipo))
+		}
+		store, err := services.NewContingencyStore(fileName)
+		if err != nil {
+			logger.Error("NewContingencyStore()", "err", err.Error())
+			os.Exit(1)
+		}
+		defer store.Close()
+		var wsfes []*services.Wsfe
+		for _, t := range sortedTenants() {
+			wsfes = append(wsfes, t.Wsfe)
+		}
+		Contingency = services.NewContingencyManager(logger, store, wsfes, interval, cbteTipos)
+		Contingency.Start(context.Background())
+		logger.Info("Modo de contingencia con CAEA", "db", fileName, "interval", interval, "cbteTipos", cbteTipos)
 	}
 
 	/* API Rest */
@@ -128,6 +442,7 @@ func main() {
 		AllowCredentials: true,
 		AllowedHeaders:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
+		ExposedHeaders:   []string{"X-Request-ID"},
 		Debug:            false,
 	})
 
@@ -136,6 +451,8 @@ func main() {
 		logger.Error("NewApiKeyMiddleware()", "err", err.Error())
 	}
 
+	middlewareTenant := middleware.NewTenantMiddleware(registry)
+
 	router := http.NewServeMux()
 
 	docs.SwaggerInfo.Host = os.Getenv("HOST")
@@ -199,26 +516,44 @@ func main() {
 	fe.HandleFunc("/FEParamGetCotizacion", FEParamGetCotizacionHandler)
 	fe.HandleFunc("/FECompTotXRequest", FECompTotXRequestHandler)
 	fe.HandleFunc("/FECAEASinMovimientoConsultar", FECAEASinMovimientoConsultarHandler)
+	fe.HandleFunc("/FECAEAConsultar", FECAEAConsultarHandler)
 	fe.HandleFunc("/FECompConsultar", FECompConsultarHandler)
 	fe.HandleFunc("/FEParamGetTiposPaises", FEParamGetTiposPaisesHandler)
 	fe.HandleFunc("/FEParamGetActividades", FEParamGetActividadesHandler)
 	fe.HandleFunc("/FEParamGetCondicionIvaReceptor", FEParamGetCondicionIvaReceptorHandler)
 	fe.HandleFunc("POST /FECAESolicitar", FECAESolicitarHandler)
 	fe.HandleFunc("POST /FECAEARegInformativo", FECAEARegInformativoHandler)
+	fe.HandleFunc("POST /FECAEASolicitar", FECAEASolicitarHandler)
+	fe.HandleFunc("POST /FECAEASinMovimientoInformar", FECAEASinMovimientoInformarHandler)
+	fe.HandleFunc("GET /contingencia", RequireContingency(ContingenciaEstadoHandler))
+	fe.HandleFunc("POST /contingencia/comprobantes", RequireContingency(ContingenciaEmitirHandler))
+	fe.HandleFunc("GET /contingencia/comprobantes", RequireContingency(ContingenciaComprobantesHandler))
+	fe.HandleFunc("PUT /contingencia/comprobantes/{id}", RequireContingency(ContingenciaCorregirHandler))
+	fe.HandleFunc("POST /contingencia/informar", RequireContingency(ContingenciaInformarHandler))
+
+	admin := http.NewServeMux()
+	admin.HandleFunc("GET /tickets", AdminTicketsHandler)
+	admin.HandleFunc("DELETE /tickets/{cuit}/{service}", AdminInvalidateTicketHandler)
+	admin.HandleFunc("POST /tickets/{cuit}/{service}/renew", AdminRenewTicketHandler)
 
 	v1 := http.NewServeMux()
 	v1.HandleFunc("/info", InfoHandler)
-	v1.Handle("/coem/", http.StripPrefix("/coem", coem))
-	v1.Handle("/coemcons/", http.StripPrefix("/coemcons", coemcons))
-	v1.Handle("/gestabref/", http.StripPrefix("/gestabref", gestabref))
-	v1.Handle("/fe/", http.StripPrefix("/fe", fe))
+	v1.HandleFunc("GET /health", HealthHandler)
+	v1.HandleFunc("GET /certificates", CertificatesHandler)
+	v1.Handle("/coem/", middlewareTenant.Handler(RequireService(func(t *Tenant) bool { return t.Wscoem != nil }, http.StripPrefix("/coem", coem))))
+	v1.Handle("/coemcons/", middlewareTenant.Handler(RequireService(func(t *Tenant) bool { return t.Wscoemcons != nil }, http.StripPrefix("/coemcons", coemcons))))
+	v1.Handle("/gestabref/", middlewareTenant.Handler(RequireService(func(t *Tenant) bool { return t.Wsgestabref != nil }, http.StripPrefix("/gestabref", gestabref))))
+	v1.Handle("/fe/", middlewareTenant.Handler(http.StripPrefix("/fe", fe)))
+	v1.Handle("/admin/", middleware.RequireAdmin(http.StripPrefix("/admin", admin)))
 	router.Handle("/api/v1/", http.StripPrefix("/api/v1", v1))
 
 	stack := middleware.CreateStack(
 		middlewareCors.Handler,
 		middleware.Recovery,
+		middleware.RequestID,
 		middleware.Logging,
 		middlewareApiKey.Handler,
+		middleware.Strict,
 	)
 
 	cfg := &tls.Config{
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/sehogas/goarca/internal/dto"
+	"github.com/sehogas/goarca/internal/services"
+	"github.com/sehogas/goarca/internal/util"
+)
+
+// Tenant agrupa los servicios de ARCA inicializados para un CUIT.
+// Los servicios aduaneros son nil si el CUIT no tiene configurado TipoAgente/Rol.
+type Tenant struct {
+	*services.Tenant
+	Wscoem      *services.Wscoem
+	Wscoemcons  *services.Wscoemcons
+	Wsgestabref *services.Wsgestabref
+	Wsfe        *services.Wsfe
+}
+
+func NewTenant(logger *slog.Logger, environment services.Environment, tenant *services.Tenant, printXML, saveXML bool) (*Tenant, error) {
+	var err error
+	t := &Tenant{Tenant: tenant}
+
+	if tenant.Wscoem != nil {
+		t.Wscoem, err = services.NewWscoem(logger, environment, tenant, printXML, saveXML)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Wscoemcons, err = services.NewWscoemcons(logger, environment, tenant, printXML, saveXML)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Warn("wscoem no habilitado: falta TipoAgente/Rol", "CUIT", tenant.Cuit)
+	}
+
+	if tenant.Wgestabref != nil {
+		t.Wsgestabref, err = services.Newgestabref(logger, environment, tenant, printXML, saveXML)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Warn("wgestabref no habilitado: falta TipoAgente/Rol", "CUIT", tenant.Cuit)
+	}
+
+	t.Wsfe, err = services.NewWsfe(logger, environment, tenant, printXML, saveXML)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// RenewalTargets devuelve los servicios habilitados del tenant cuyos tickets se renuevan en segundo plano.
+func (t *Tenant) RenewalTargets() []services.RenewalTarget {
+	targets := []services.RenewalTarget{{Tenant: t.Tenant, ServiceName: t.Wsfe.ServiceName()}}
+	if t.Wscoem != nil {
+		targets = append(targets,
+			services.RenewalTarget{Tenant: t.Tenant, ServiceName: t.Wscoem.ServiceName()},
+			services.RenewalTarget{Tenant: t.Tenant, ServiceName: t.Wscoemcons.ServiceName()})
+	}
+	if t.Wsgestabref != nil {
+		targets = append(targets, services.RenewalTarget{Tenant: t.Tenant, ServiceName: t.Wsgestabref.ServiceName()})
+	}
+	return targets
+}
+
+// sortedTenants devuelve los tenants ordenados por CUIT.
+func sortedTenants() []*Tenant {
+	result := make([]*Tenant, 0, len(Tenants))
+	for _, cuit := range slices.Sorted(maps.Keys(Tenants)) {
+		result = append(result, Tenants[cuit])
+	}
+	return result
+}
+
+// tenant devuelve los servicios del CUIT de la solicitud, resuelto por middleware.TenantMiddleware.
+func tenant(r *http.Request) *Tenant {
+	t, _ := services.TenantFromContext(r.Context())
+	return Tenants[t.Cuit]
+}
+
+func Wscoem(r *http.Request) *services.Wscoem {
+	return tenant(r).Wscoem
+}
+
+func Wscoemcons(r *http.Request) *services.Wscoemcons {
+	return tenant(r).Wscoemcons
+}
+
+func Wsgestabref(r *http.Request) *services.Wsgestabref {
+	return tenant(r).Wsgestabref
+}
+
+func Wsfe(r *http.Request) *services.Wsfe {
+	return tenant(r).Wsfe
+}
+
+// RequireService rechaza las solicitudes de CUIT que no tienen habilitado el servicio.
+func RequireService(enabled func(t *Tenant) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !enabled(tenant(r)) {
+			err := errors.New("servicio no habilitado para el CUIT")
+			util.HttpResponseJSON(w, http.StatusForbidden, &dto.ErrorResponse{Error: err.Error()}, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
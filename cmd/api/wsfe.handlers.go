@@ -17,17 +17,23 @@ import (
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.DummyResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEDummy [get]
 func FEDummyHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEDummy()
+	resultado, err := Wsfe(r).FEDummy(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FECompUltimoAutorizadoHandler godoc
@@ -37,11 +43,18 @@ func FEDummyHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			ptoVta		query		string	true	"Punto de venta"
 //	@Param			cbteTipo	query		string	true	"Tipo de comprobante"
 //	@Success		200			{object}	wsfe.FERecuperaLastCbteResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FECompUltimoAutorizado [get]
 func FECompUltimoAutorizadoHandler(w http.ResponseWriter, r *http.Request) {
 	ptoVtaStr := r.URL.Query().Get("ptoVta")
@@ -61,12 +74,12 @@ func FECompUltimoAutorizadoHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wsfe.FEUltimoComprobanteEmitido(int32(ptoVta), int32(cbteTipo))
+	resultado, err := Wsfe(r).FEUltimoComprobanteEmitido(r.Context(), int32(ptoVta), int32(cbteTipo))
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FECAESolicitarHandler godoc
@@ -76,11 +89,18 @@ func FECompUltimoAutorizadoHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string						true	"API Key de acceso"
+//	@Param			x-cuit		header		string						false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool						false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		dto.FECAESolicitarRequest	true	"FECAESolicitarRequest"
 //	@Success		200			{object}	wsfe.FECAEResponse
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FECAESolicitar [post]
 func FECAESolicitarHandler(w http.ResponseWriter, r *http.Request) {
 	var post dto.FECAESolicitarRequest
@@ -90,12 +110,12 @@ func FECAESolicitarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wsfe.FECAESolicitar(post.Cab, post.Det)
+	resultado, err := Wsfe(r).FECAESolicitar(r.Context(), post.Cab, post.Det)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetTiposCbteHandler godoc
@@ -105,17 +125,24 @@ func FECAESolicitarHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.CbteTipoResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposCbte [get]
 func FEParamGetTiposCbteHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposCbte()
+	resultado, err := Wsfe(r).FEParamGetTiposCbte(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetTiposConceptoHandler godoc
@@ -125,17 +152,24 @@ func FEParamGetTiposCbteHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.ConceptoTipoResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposConcepto [get]
 func FEParamGetTiposConceptoHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposConcepto()
+	resultado, err := Wsfe(r).FEParamGetTiposConcepto(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetTiposDocHandler godoc
@@ -145,17 +179,24 @@ func FEParamGetTiposConceptoHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.DocTipoResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposDoc [get]
 func FEParamGetTiposDocHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposDoc()
+	resultado, err := Wsfe(r).FEParamGetTiposDoc(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetTiposIvaHandler godoc
@@ -165,17 +206,24 @@ func FEParamGetTiposDocHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.IvaTipoResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposIva [get]
 func FEParamGetTiposIvaHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposIva()
+	resultado, err := Wsfe(r).FEParamGetTiposIva(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetTiposMonedasHandler godoc
@@ -185,17 +233,24 @@ func FEParamGetTiposIvaHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.MonedaResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposMonedas [get]
 func FEParamGetTiposMonedasHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposMonedas()
+	resultado, err := Wsfe(r).FEParamGetTiposMonedas(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetTiposOpcionalHandler godoc
@@ -205,17 +260,24 @@ func FEParamGetTiposMonedasHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.OpcionalTipoResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposOpcional [get]
 func FEParamGetTiposOpcionalHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposOpcional()
+	resultado, err := Wsfe(r).FEParamGetTiposOpcional(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetTiposTributosHandler godoc
@@ -225,17 +287,24 @@ func FEParamGetTiposOpcionalHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.FETributoResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposTributos [get]
 func FEParamGetTiposTributosHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposTributos()
+	resultado, err := Wsfe(r).FEParamGetTiposTributos(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetPtosVentaHandler godoc
@@ -245,17 +314,24 @@ func FEParamGetTiposTributosHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.FEPtoVentaResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetPtosVenta [get]
 func FEParamGetPtosVentaHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetPtosVenta()
+	resultado, err := Wsfe(r).FEParamGetPtosVenta(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetCotizacionHandler godoc
@@ -265,11 +341,18 @@ func FEParamGetPtosVentaHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			monId		query		string	true	"código de moneda"
 //	@Param			fchCotiz	query		string	false	"fecha de cotización"
 //	@Success		200			{object}	wsfe.FECotizacionResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetCotizacion [get]
 func FEParamGetCotizacionHandler(w http.ResponseWriter, r *http.Request) {
 	monIdStr := r.URL.Query().Get("monId")
@@ -281,12 +364,12 @@ func FEParamGetCotizacionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cotizacion, err := Wsfe.FEParamGetCotizacion(monIdStr, fchCotiz)
+	cotizacion, err := Wsfe(r).FEParamGetCotizacion(r.Context(), monIdStr, fchCotiz)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, cotizacion, nil)
+	HttpResponseResult(w, r, cotizacion)
 }
 
 // FECompTotXRequestHandler godoc
@@ -296,17 +379,24 @@ func FEParamGetCotizacionHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.FERegXReqResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FECompTotXRequest [get]
 func FECompTotXRequestHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FECompTotXRequest()
+	resultado, err := Wsfe(r).FECompTotXRequest(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FECAEARegInformativo godoc
@@ -316,11 +406,18 @@ func FECompTotXRequestHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string						true	"API Key de acceso"
+//	@Param			x-cuit		header		string						false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool						false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			request		body		dto.FeCAEARegInfReqRequest	true	"FeCAEARegInfReqRequest"
 //	@Success		200			{object}	wsfe.FECAEAResponse
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FECAEARegInformativo [post]
 func FECAEARegInformativoHandler(w http.ResponseWriter, r *http.Request) {
 	var post dto.FeCAEARegInfReqRequest
@@ -330,12 +427,12 @@ func FECAEARegInformativoHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resultado, err := Wsfe.FECAEARegInformativo(post.Cab, post.Det)
+	resultado, err := Wsfe(r).FECAEARegInformativo(r.Context(), post.Cab, post.Det)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FECAEASinMovimientoConsultarHandler godoc
@@ -345,11 +442,18 @@ func FECAEARegInformativoHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			CAEA		query		string	true	"CAEA"
 //	@Param			PtoVta		query		string	true	"Punto de Venta"
-//	@Success		200			{object}	wsfe.FECotizacionResponse
+//	@Success		200			{object}	wsfe.FECAEASinMovConsResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FECAEASinMovimientoConsultar [get]
 func FECAEASinMovimientoConsultarHandler(w http.ResponseWriter, r *http.Request) {
 	caea := r.URL.Query().Get("CAEA")
@@ -368,12 +472,155 @@ func FECAEASinMovimientoConsultarHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	cotizacion, err := Wsfe.FECAEASinMovimientoConsultar(caea, int32(ptoVta))
+	cotizacion, err := Wsfe(r).FECAEASinMovimientoConsultar(r.Context(), caea, int32(ptoVta))
+	if err != nil {
+		HttpResponseError(w, err)
+		return
+	}
+	HttpResponseResult(w, r, cotizacion)
+}
+
+// FECAEASolicitarHandler godoc
+//
+//	@Summary		Solicitar CAEA
+//	@Description	Este método permite solicitar un CAEA para la quincena indicada. Puede solicitarse dentro de los 5 días corridos anteriores al comienzo de cada quincena. Si el CAEA ya fue otorgado, se obtiene con FECAEAConsultar.
+//	@Tags			Factura Electrónica
+//	@Produce		json
+//	@Param			x-api-key	header		string						true	"API Key de acceso"
+//	@Param			x-cuit		header		string						false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool						false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
+//	@Param			request		body		dto.FECAEASolicitarRequest	true	"FECAEASolicitarRequest"
+//	@Success		200			{object}	wsfe.FECAEAGetResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
+//	@Router			/fe/FECAEASolicitar [post]
+func FECAEASolicitarHandler(w http.ResponseWriter, r *http.Request) {
+	var post dto.FECAEASolicitarRequest
+	err := json.NewDecoder(r.Body).Decode(&post)
+	if err != nil {
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: "error leyendo parámetros de la solicitud"}, err)
+		return
+	}
+
+	if err := validarQuincena(post.Periodo, post.Orden); err != nil {
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	resultado, err := Wsfe(r).FECAEASolicitar(r.Context(), post.Periodo, post.Orden)
+	if err != nil {
+		HttpResponseError(w, err)
+		return
+	}
+	HttpResponseResult(w, r, resultado)
+}
+
+// FECAEAConsultarHandler godoc
+//
+//	@Summary		Consultar CAEA
+//	@Description	Este método permite consultar la información correspondiente a un CAEA previamente otorgado para un período/orden.
+//	@Tags			Factura Electrónica
+//	@Produce		json
+//	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
+//	@Param			Periodo		query		string	true	"Año y mes de la quincena (aaaamm)"
+//	@Param			Orden		query		string	true	"Quincena: 1 (del 1 al 15) o 2 (del 16 a fin de mes)"
+//	@Success		200			{object}	wsfe.FECAEAGetResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
+//	@Router			/fe/FECAEAConsultar [get]
+func FECAEAConsultarHandler(w http.ResponseWriter, r *http.Request) {
+	periodo, err := strconv.ParseInt(r.URL.Query().Get("Periodo"), 10, 32)
+	if err != nil {
+		err := errors.New("error leyendo parámetro Periodo")
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	orden, err := strconv.ParseInt(r.URL.Query().Get("Orden"), 10, 16)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		err := errors.New("error leyendo parámetro Orden")
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	if err := validarQuincena(int32(periodo), int16(orden)); err != nil {
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, cotizacion, nil)
+
+	resultado, err := Wsfe(r).FECAEAConsultar(r.Context(), int32(periodo), int16(orden))
+	if err != nil {
+		HttpResponseError(w, err)
+		return
+	}
+	HttpResponseResult(w, r, resultado)
+}
+
+// FECAEASinMovimientoInformarHandler godoc
+//
+//	@Summary		Informar CAEA sin movimiento
+//	@Description	Esta operación permite informar que un CAEA otorgado no registró comprobantes asociados en un punto de venta. Debe informarse hasta la fecha tope de información del CAEA (FchTopeInf).
+//	@Tags			Factura Electrónica
+//	@Produce		json
+//	@Param			x-api-key	header		string									true	"API Key de acceso"
+//	@Param			x-cuit		header		string									false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool									false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
+//	@Param			request		body		dto.FECAEASinMovimientoInformarRequest	true	"FECAEASinMovimientoInformarRequest"
+//	@Success		200			{object}	wsfe.FECAEASinMovResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
+//	@Router			/fe/FECAEASinMovimientoInformar [post]
+func FECAEASinMovimientoInformarHandler(w http.ResponseWriter, r *http.Request) {
+	var post dto.FECAEASinMovimientoInformarRequest
+	err := json.NewDecoder(r.Body).Decode(&post)
+	if err != nil {
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: "error leyendo parámetros de la solicitud"}, err)
+		return
+	}
+
+	if post.CAEA == "" || post.PtoVta <= 0 {
+		err := errors.New("debe indicar CAEA y PtoVta")
+		util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: err.Error()}, err)
+		return
+	}
+
+	resultado, err := Wsfe(r).FECAEASinMovimientoInformar(r.Context(), post.CAEA, post.PtoVta)
+	if err != nil {
+		HttpResponseError(w, err)
+		return
+	}
+	HttpResponseResult(w, r, resultado)
+}
+
+// validarQuincena verifica el período (aaaamm) y el orden (1 o 2) de un CAEA.
+func validarQuincena(periodo int32, orden int16) error {
+	if periodo < 200001 || periodo%100 < 1 || periodo%100 > 12 {
+		return errors.New("parámetro Periodo inválido: debe tener el formato aaaamm")
+	}
+	if orden != 1 && orden != 2 {
+		return errors.New("parámetro Orden inválido: debe ser 1 o 2")
+	}
+	return nil
 }
 
 // FECompConsultarHandler godoc
@@ -383,12 +630,19 @@ func FECAEASinMovimientoConsultarHandler(w http.ResponseWriter, r *http.Request)
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			PtoVta		query		string	true	"Punto de Venta"
 //	@Param			CbteTipo	query		string	true	"Tipo de Comprobante"
 //	@Param			CbteNro		query		string	true	"Número de Comprobante"
 //	@Success		200			{object}	wsfe.FECompConsultaResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FECompConsultar [get]
 func FECompConsultarHandler(w http.ResponseWriter, r *http.Request) {
 	ptoVtaStr := r.URL.Query().Get("PtoVta")
@@ -416,12 +670,12 @@ func FECompConsultarHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cotizacion, err := Wsfe.FECompConsultar(int32(ptoVta), int32(cbteTipo), cbteNro)
+	cotizacion, err := Wsfe(r).FECompConsultar(r.Context(), int32(ptoVta), int32(cbteTipo), cbteNro)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, cotizacion, nil)
+	HttpResponseResult(w, r, cotizacion)
 }
 
 // FEParamGetTiposPaisesHandler godoc
@@ -431,17 +685,24 @@ func FECompConsultarHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.FEPaisResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetTiposPaises [get]
 func FEParamGetTiposPaisesHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetTiposPaises()
+	resultado, err := Wsfe(r).FEParamGetTiposPaises(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetActividadesHandler godoc
@@ -451,17 +712,24 @@ func FEParamGetTiposPaisesHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Success		200			{object}	wsfe.FEActividadesResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetActividades [get]
 func FEParamGetActividadesHandler(w http.ResponseWriter, r *http.Request) {
-	resultado, err := Wsfe.FEParamGetActividades()
+	resultado, err := Wsfe(r).FEParamGetActividades(r.Context())
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
 
 // FEParamGetCondicionIvaReceptorHandler godoc
@@ -471,10 +739,17 @@ func FEParamGetActividadesHandler(w http.ResponseWriter, r *http.Request) {
 //	@Tags			Factura Electrónica
 //	@Produce		json
 //	@Param			x-api-key	header		string	true	"API Key de acceso"
+//	@Param			x-cuit		header		string	false	"CUIT con el que se opera (requerido si la API Key habilita más de uno)"
+//	@Param			x-strict	header		bool	false	"Modo estricto: responde 422 si ARCA rechaza la solicitud e informa las advertencias en warnings (también con el parámetro strict)"
 //	@Param			ClaseCmp	query		string	true	"Clase de Comprobate"
 //	@Success		200			{object}	wsfe.CondicionIvaReceptorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
+//	@Failure		502			{object}	dto.ErrorResponse
+//	@Failure		503			{object}	dto.ErrorResponse
+//	@Failure		504			{object}	dto.ErrorResponse
 //	@Router			/fe/FEParamGetCondicionIvaReceptor [get]
 func FEParamGetCondicionIvaReceptorHandler(w http.ResponseWriter, r *http.Request) {
 	claseCmp := r.URL.Query().Get("ClaseCmp")
@@ -484,10 +759,10 @@ func FEParamGetCondicionIvaReceptorHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resultado, err := Wsfe.FEParamGetCondicionIvaReceptor(claseCmp)
+	resultado, err := Wsfe(r).FEParamGetCondicionIvaReceptor(r.Context(), claseCmp)
 	if err != nil {
-		util.HttpResponseJSON(w, http.StatusInternalServerError, &dto.ErrorResponse{Error: err.Error()}, err)
+		HttpResponseError(w, err)
 		return
 	}
-	util.HttpResponseJSON(w, http.StatusOK, resultado, nil)
+	HttpResponseResult(w, r, resultado)
 }
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sehogas/goarca/internal/services"
+	"github.com/sehogas/goarca/internal/util"
+)
+
+// Herramientas sobre los intercambios con ARCA guardados con SAVE_XML y el archivo fiscal (ARCHIVE_DIR).
+//
+//	go run ./cmd/audit decrypt -key-file keys/audit.key xml/raw/20999999992/wsfe/.../archivo.http.gz.enc
+//	go run ./cmd/audit decrypt -key-file keys/archive.key archive/20999999992.jsonl
+//	go run ./cmd/audit verify -cert keys/certificado.pem archive/20999999992.jsonl
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "decrypt":
+		err = decrypt(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Uso: audit decrypt [-key-file archivo] archivo...")
+	fmt.Fprintln(os.Stderr, "     audit verify [-cert certificado[,certificado...]] archivo.jsonl...")
+	os.Exit(2)
+}
+
+// decrypt muestra los intercambios sin ocultar guardados con AUDIT_RAW=true, descifrados y descomprimidos, y las
+// entradas de los archivos fiscales (.jsonl) guardados con ARCHIVE_KEY, con la solicitud y la respuesta en claro.
+func decrypt(args []string) error {
+	flags := flag.NewFlagSet("decrypt", flag.ExitOnError)
+	keyFile := flags.String("key-file", "", "Archivo con la clave de cifrado (por defecto AUDIT_RAW_KEY_FILE o AUDIT_RAW_KEY)")
+	flags.Parse(args)
+
+	if *keyFile == "" {
+		*keyFile = os.Getenv("AUDIT_RAW_KEY_FILE")
+	}
+	keyText, err := util.ReadPassphrase(*keyFile, "AUDIT_RAW_KEY")
+	if err != nil {
+		return err
+	}
+	key, err := services.ParseAuditKey(keyText)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range flags.Args() {
+		if strings.HasSuffix(file, ".jsonl") {
+			if err := decryptArchive(file, key); err != nil {
+				return fmt.Errorf("%s: %s", file, err)
+			}
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		data, err = services.DecryptAuditObject(key, data)
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+		if strings.HasSuffix(strings.TrimSuffix(file, ".enc"), ".gz") {
+			zr, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("%s: %s", file, err)
+			}
+			data, err = io.ReadAll(zr)
+			if err != nil {
+				return fmt.Errorf("%s: %s", file, err)
+			}
+		}
+		os.Stdout.Write(data)
+		fmt.Println()
+	}
+	return nil
+}
+
+// decryptArchive muestra las entradas del archivo fiscal con la solicitud y la respuesta descifradas.
+func decryptArchive(file string, key []byte) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry services.ArchiveEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("línea %d: %s", line, err)
+		}
+		if err := entry.Decrypt(key); err != nil {
+			return fmt.Errorf("línea %d: %s", line, err)
+		}
+		encoder.Encode(entry)
+	}
+	return scanner.Err()
+}
+
+// verify comprueba la secuencia, la cadena de hashes y las firmas de los archivos fiscales.
+func verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	certFiles := flags.String("cert", "", "Certificados con los que se firmaron las entradas, separados por coma")
+	flags.Parse(args)
+
+	var certificates []*x509.Certificate
+	for _, file := range util.SplitList(*certFiles) {
+		certificate, err := util.ReadCertificate(file)
+		if err != nil {
+			return fmt.Errorf("certificado [%s]: %s", file, err)
+		}
+		if certificate == nil {
+			return fmt.Errorf("certificado [%s]: no se encontró un certificado", file)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	var failed bool
+	for _, file := range flags.Args() {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		report, err := services.VerifyArchive(f, certificates)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+
+		fmt.Printf("%s: %d entradas, %d firmadas, última secuencia %d, último hash %s\n", file, report.Entries, report.Signed, report.LastSeq, report.LastHash)
+		if report.Signed > 0 && len(certificates) == 0 {
+			fmt.Println("  las firmas no se verificaron (indique -cert)")
+		}
+		for _, problem := range report.Problems {
+			fmt.Println("  " + problem)
+		}
+		if len(report.Problems) > 0 {
+			failed = true
+		}
+	}
+	if failed {
+		return errors.New("el archivo fiscal fue modificado o está incompleto")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sehogas/goarca/internal/util"
+)
+
+// Genera la clave privada y la solicitud de certificado (CSR) para tramitar el certificado
+// de ARCA, con el sujeto C=AR, O=<organización>, CN=<nombre> y serialNumber=CUIT <cuit>.
+//
+//	go run ./cmd/csr -cuit 20999999992 -o "Mi Empresa" -cn goarca
+func main() {
+	cuit := flag.Int64("cuit", 0, "CUIT del titular del certificado")
+	organization := flag.String("o", "", "Organización (razón social)")
+	commonName := flag.String("cn", "", "Nombre del certificado (alias con el que se identifica en ARCA)")
+	keyFile := flag.String("key", "MiClavePrivada", "Archivo de la clave privada a generar")
+	csrFile := flag.String("csr", "misolicitud.csr", "Archivo de la solicitud de certificado a generar")
+	keyType := flag.String("type", "rsa", "Tipo de clave: rsa o ec")
+	bits := flag.Int("bits", 2048, "Longitud de la clave RSA")
+	passphraseFile := flag.String("passphrase-file", "", "Archivo con la frase de paso para cifrar la clave (por defecto PRIVATE_KEY_PASSPHRASE)")
+	force := flag.Bool("force", false, "Sobrescribe la clave privada si ya existe")
+	flag.Parse()
+
+	if err := run(*cuit, *organization, *commonName, *keyFile, *csrFile, *keyType, *bits, *passphraseFile, *force); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(cuit int64, organization, commonName, keyFile, csrFile, keyType string, bits int, passphraseFile string, force bool) error {
+	if !util.ValidCuit(cuit) {
+		return fmt.Errorf("CUIT inválido [%d]", cuit)
+	}
+	if organization == "" || commonName == "" {
+		return errors.New("debe indicar la organización (-o) y el nombre (-cn)")
+	}
+	if _, err := os.Stat(keyFile); err == nil && !force {
+		return fmt.Errorf("la clave privada [%s] ya existe (utilice -force para reemplazarla)", keyFile)
+	}
+
+	var key crypto.Signer
+	var err error
+	switch keyType {
+	case "rsa":
+		if bits < 2048 {
+			return errors.New("ARCA exige claves RSA de al menos 2048 bits")
+		}
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	case "ec":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return fmt.Errorf("tipo de clave no soportado [%s]", keyType)
+	}
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := util.ReadPassphrase(passphraseFile, "PRIVATE_KEY_PASSPHRASE")
+	if err != nil {
+		return err
+	}
+	var block *pem.Block
+	if len(passphrase) > 0 {
+		block, err = util.EncryptPKCS8(key, passphrase)
+	} else {
+		var der []byte
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+	if err != nil {
+		return err
+	}
+
+	csr, err := util.CreateCSR(key, cuit, organization, commonName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(csrFile, csr, 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Clave privada: %s", keyFile)
+	if len(passphrase) > 0 {
+		fmt.Print(" (cifrada)")
+	}
+	fmt.Printf("\nSolicitud de certificado: %s\n", csrFile)
+	fmt.Println("Suba la solicitud en ARCA (Administración de Certificados Digitales o WSASS) y guarde el certificado obtenido en formato PEM.")
+	return nil
+}
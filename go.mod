@@ -2,15 +2,29 @@ module github.com/sehogas/goarca
 
 go 1.25.1
 
-require go.mozilla.org/pkcs7 v0.9.0
-
 require (
 	github.com/hooklift/gowsdl v0.5.0
 	github.com/joho/godotenv v1.5.1
+	github.com/miekg/pkcs11 v1.1.1
 	github.com/rs/cors v1.11.1
+	go.mozilla.org/pkcs7 v0.9.0
+	go.mozilla.org/pkcs7 v0.9.0
+	modernc.org/sqlite v1.37.0
+	software.sslmate.com/src/go-pkcs12 v0.5.0
 )
 
-require github.com/swaggo/files/v2 v2.0.0 // indirect
+require (
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	github.com/swaggo/files/v2 v2.0.0 // indirect
+	golang.org/x/exp v0.0.0-20250305212735-054e65f0b394 // indirect
+	modernc.org/libc v1.62.1 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.9.1 // indirect
+)
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
@@ -28,7 +42,7 @@ require (
 	github.com/swaggo/http-swagger/v2 v2.0.2
 	github.com/swaggo/swag v1.16.4
 	golang.org/x/crypto v0.37.0 // indirect
-	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/net v0.39.0
 	golang.org/x/sys v0.32.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
 	golang.org/x/tools v0.32.0 // indirect
@@ -29,3 +29,28 @@ type FeCAEARegInfReqRequest struct {
 	Cab *wsfe.FECabRequest       `json:"Cabecera"`
 	Det []*wsfe.FECAEADetRequest `json:"Detalle"`
 }
+
+type FECAEASolicitarRequest struct {
+	// Periodo es el año y mes (aaaamm) de la quincena.
+	Periodo int32 `json:"Periodo" example:"202610"`
+	// Orden es la quincena: 1 (del 1 al 15) o 2 (del 16 a fin de mes).
+	Orden int16 `json:"Orden" example:"1"`
+}
+
+type FECAEASinMovimientoInformarRequest struct {
+	CAEA   string `json:"CAEA" example:"36423456789012"`
+	PtoVta int32  `json:"PtoVta" example:"5"`
+}
+
+type ContingenciaComprobanteRequest struct {
+	PtoVta   int32 `json:"PtoVta" example:"5"`
+	CbteTipo int32 `json:"CbteTipo" example:"6"`
+	// Detalle es el comprobante sin número ni CAEA, que se asignan al emitirlo. Si CbteFch está vacía se
+	// utiliza la fecha actual.
+	Detalle *wsfe.FECAEADetRequest `json:"Detalle"`
+}
+
+type ContingenciaCorreccionRequest struct {
+	// Detalle es el comprobante corregido. El número, la fecha y el CAEA se conservan los del comprobante rechazado.
+	Detalle *wsfe.FECAEADetRequest `json:"Detalle"`
+}
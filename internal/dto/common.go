@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type GenericResponse struct {
 	Status     bool        `json:"status" default:"false"`
 	StatusCode int         `json:"statusCode,omitempty"`
@@ -8,8 +10,32 @@ type GenericResponse struct {
 	TotalRows  int64       `json:"total_rows,omitempty"`
 }
 
+// Tipos de error informados en ErrorResponse.Type.
+const (
+	ErrorInternal    = "internal"
+	ErrorUnavailable = "unavailable"
+	ErrorTimeout     = "timeout"
+	ErrorTransport   = "transport"
+	ErrorSoapFault   = "soap_fault"
+	ErrorAuth        = "auth"
+	ErrorArca        = "arca"
+)
+
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error  string        `json:"error"`
+	Type   string        `json:"type,omitempty" enums:"internal,unavailable,timeout,transport,soap_fault,auth,arca"`
+	Code   string        `json:"code,omitempty"`
+	Errors []ErrorDetail `json:"errors,omitempty"`
+	// Warnings son los eventos y observaciones de la respuesta de ARCA, informados en modo estricto.
+	Warnings []ErrorDetail `json:"warnings,omitempty"`
+	// Result es la respuesta de ARCA, informada en modo estricto: en una aprobación parcial contiene los CAE otorgados.
+	Result any `json:"result,omitempty" swaggertype:"object"`
+}
+
+// ErrorDetail es un error o advertencia de negocio informado por ARCA, con su código original.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type MessageResponse struct {
@@ -23,5 +49,55 @@ type SendEmailRequest struct {
 }
 
 type InfoResponse struct {
-	Version string `json:"version"`
+	Version   string                `json:"version"`
+	Endpoints map[string]string     `json:"endpoints"`
+	Tickets   []TicketRenewalStatus `json:"tickets,omitempty"`
+}
+
+type TicketRenewalStatus struct {
+	ServiceName    string    `json:"service"`
+	Cuit           int64     `json:"cuit"`
+	ExpirationTime time.Time `json:"expirationTime,omitzero"`
+	LastRenewal    time.Time `json:"lastRenewal,omitzero"`
+	NextAttempt    time.Time `json:"nextAttempt,omitzero"`
+	Failures       int       `json:"failures"`
+	LastError      string    `json:"lastError,omitempty"`
+}
+
+type CertificateStatus struct {
+	Cuit         int64     `json:"cuit"`
+	Subject      string    `json:"subject,omitempty"`
+	Issuer       string    `json:"issuer,omitempty"`
+	SerialNumber string    `json:"serialNumber,omitempty"`
+	NotBefore    time.Time `json:"notBefore,omitzero"`
+	NotAfter     time.Time `json:"notAfter,omitzero"`
+	DaysLeft     int       `json:"daysLeft"`
+	Status       string    `json:"status" enums:"valid,warning,expired,error"`
+	Error        string    `json:"error,omitempty"`
+	CheckedAt    time.Time `json:"checkedAt"`
+}
+
+type TicketInfo struct {
+	ServiceName    string    `json:"service"`
+	Cuit           int64     `json:"cuit"`
+	GenerationTime time.Time `json:"generationTime,omitzero"`
+	ExpirationTime time.Time `json:"expirationTime,omitzero"`
+	Source         string    `json:"source" enums:"memory,file,sql,redis,none"`
+	Valid          bool      `json:"valid"`
+	BlockedUntil   time.Time `json:"blockedUntil,omitzero"`
+	Error          string    `json:"error,omitempty"`
+}
+
+type HealthResponse struct {
+	Status   string          `json:"status" enums:"ok,degraded"`
+	Breakers []BreakerStatus `json:"breakers"`
+}
+
+type BreakerStatus struct {
+	URL       string    `json:"url"`
+	State     string    `json:"state" enums:"closed,open,half-open"`
+	Failures  int       `json:"failures"`
+	OpenedAt  time.Time `json:"openedAt,omitzero"`
+	RetryAt   time.Time `json:"retryAt,omitzero"`
+	LastError string    `json:"lastError,omitempty"`
 }
@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"os"
+	"strconv"
 	"strings"
 
 	"net/http"
@@ -14,26 +16,35 @@ import (
 
 var ErrNoApiKey = errors.New("no api Key defined")
 var ErrInvalidApiKey = errors.New("invalid api Key")
+var ErrNotAdmin = errors.New("la API Key no tiene permisos de administración")
 
 type ApiKeyMiddleware struct {
-	keys []string
+	// keys asocia cada API Key con los CUIT que puede operar (nil: todos).
+	keys map[string][]int64
+	// admins son las API Keys definidas con ADMIN_KEY_, que además pueden usar los endpoints de administración.
+	admins map[string]bool
 }
 
+type allowedCuitsContextKey struct{}
+
+type adminContextKey struct{}
+
 func NewApiKeyMiddleware(filename string) (*ApiKeyMiddleware, error) {
-	keys, err := getKeysFromFile(filename)
+	keys, admins, err := getKeysFromFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ApiKeyMiddleware{
-		keys: keys,
+		keys:   keys,
+		admins: admins,
 	}, nil
 }
 
 func (m *ApiKeyMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Salteo control porque swagger es público
-		if strings.HasPrefix(r.URL.Path, "/swagger") {
+		// Salteo control porque swagger y el estado de la API son públicos
+		if strings.HasPrefix(r.URL.Path, "/swagger") || r.URL.Path == "/api/v1/health" {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -43,18 +54,41 @@ func (m *ApiKeyMiddleware) Handler(next http.Handler) http.Handler {
 			util.HttpResponseJSON(w, http.StatusUnauthorized, &dto.ErrorResponse{Error: "invalid api key"}, errors.New("invalid api key"))
 			return
 		}
+		ctx := context.WithValue(r.Context(), allowedCuitsContextKey{}, m.keys[authorization])
+		ctx = context.WithValue(ctx, adminContextKey{}, m.admins[authorization])
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireAdmin rechaza las solicitudes cuya API Key no fue definida con ADMIN_KEY_.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if admin, _ := r.Context().Value(adminContextKey{}).(bool); !admin {
+			util.HttpResponseJSON(w, http.StatusForbidden, &dto.ErrorResponse{Error: ErrNotAdmin.Error()}, ErrNotAdmin)
+			return
+		}
 		next.ServeHTTP(w, r)
 	})
 }
 
-func getKeysFromFile(fileName string) ([]string, error) {
-	var keys []string
+// AllowedCuits devuelve los CUIT que puede operar la API Key de la solicitud (nil: todos).
+func AllowedCuits(r *http.Request) []int64 {
+	allowed, _ := r.Context().Value(allowedCuitsContextKey{}).([]int64)
+	return allowed
+}
+
+// getKeysFromFile lee las API Keys con formato API_KEY_<NOMBRE>=<key>[:<cuit>[,<cuit>...]].
+// Si se indican CUIT, la key sólo puede operar con esos CUIT. Las keys con formato
+// ADMIN_KEY_<NOMBRE>=<key>[:<cuit>...] además pueden usar los endpoints de administración.
+func getKeysFromFile(fileName string) (map[string][]int64, map[string]bool, error) {
+	keys := make(map[string][]int64)
+	admins := make(map[string]bool)
 	file, err := os.Open(fileName)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return keys, nil
+			return keys, admins, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	defer file.Close()
 	scanner := bufio.NewScanner(file)
@@ -66,28 +100,34 @@ func getKeysFromFile(fileName string) ([]string, error) {
 		if len(pair) == 2 {
 			key := pair[0]
 			value := pair[1]
-			if strings.HasPrefix(key, "API_KEY_") {
-				keys = append(keys, value)
+			if strings.HasPrefix(key, "API_KEY_") || strings.HasPrefix(key, "ADMIN_KEY_") {
+				apiKey, cuitList, _ := strings.Cut(value, ":")
+				var cuits []int64
+				for _, c := range strings.Split(cuitList, ",") {
+					if c = strings.TrimSpace(c); c == "" {
+						continue
+					}
+					cuit, err := strconv.ParseInt(c, 10, 64)
+					if err != nil {
+						return nil, nil, errors.New("invalid cuit for " + key)
+					}
+					cuits = append(cuits, cuit)
+				}
+				keys[apiKey] = cuits
+				if strings.HasPrefix(key, "ADMIN_KEY_") {
+					admins[apiKey] = true
+				}
 			}
 		}
 	}
-	return keys, nil
-}
-
-func keyInKeys(key string, keys []string) bool {
-	for _, k := range keys {
-		if key == k {
-			return true
-		}
-	}
-	return false
+	return keys, admins, nil
 }
 
 func (m *ApiKeyMiddleware) CheckAPIKey(key string) error {
 	if key == "" {
 		return ErrNoApiKey
 	}
-	if !keyInKeys(key, m.keys) {
+	if _, exist := m.keys[key]; !exist {
 		return ErrInvalidApiKey
 	}
 	return nil
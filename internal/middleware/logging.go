@@ -4,6 +4,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"github.com/sehogas/goarca/internal/services"
 )
 
 type wrappedWriter struct {
@@ -26,6 +28,6 @@ func Logging(next http.Handler) http.Handler {
 		}
 
 		next.ServeHTTP(wrapper, r)
-		log.Println(wrapper.statusCode, r.Method, r.URL.Path, time.Since(start))
+		log.Println(wrapper.statusCode, r.Method, r.URL.Path, time.Since(start), services.RequestIDFromContext(r.Context()))
 	})
 }
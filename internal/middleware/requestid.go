@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/sehogas/goarca/internal/services"
+)
+
+// validRequestID limita los identificadores recibidos, que se utilizan en nombres de archivo y en el log.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID identifica cada solicitud con el header X-Request-ID recibido o con uno generado, lo devuelve
+// en la respuesta y lo asocia a los intercambios con ARCA que se capturan durante la solicitud.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get("X-Request-ID")
+		if !validRequestID.MatchString(requestID) {
+			requestID = services.NewRequestID()
+		}
+		w.Header().Set("X-Request-ID", requestID)
+		next.ServeHTTP(w, r.WithContext(services.ContextWithRequestID(r.Context(), requestID)))
+	})
+}
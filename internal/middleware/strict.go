@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sehogas/goarca/internal/dto"
+	"github.com/sehogas/goarca/internal/services"
+	"github.com/sehogas/goarca/internal/util"
+)
+
+var ErrInvalidStrict = errors.New("el header x-strict y el parámetro strict deben ser true o false")
+
+// Strict activa el modo estricto de la solicitud con el header x-strict o el parámetro strict: los
+// comprobantes rechazados o aprobados parcialmente por ARCA se responden con 422 y las advertencias
+// de la respuesta se informan en warnings.
+func Strict(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := strings.TrimSpace(r.Header.Get("x-strict"))
+		if value == "" {
+			value = strings.TrimSpace(r.URL.Query().Get("strict"))
+		}
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		strict, err := strconv.ParseBool(value)
+		if err != nil {
+			util.HttpResponseJSON(w, http.StatusBadRequest, &dto.ErrorResponse{Error: ErrInvalidStrict.Error()}, ErrInvalidStrict)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(services.ContextWithStrict(r.Context(), strict)))
+	})
+}
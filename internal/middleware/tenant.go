@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sehogas/goarca/internal/dto"
+	"github.com/sehogas/goarca/internal/services"
+	"github.com/sehogas/goarca/internal/util"
+)
+
+var ErrNoTenant = errors.New("debe indicar el CUIT en el header x-cuit")
+var ErrInvalidTenant = errors.New("CUIT no habilitado")
+
+type TenantMiddleware struct {
+	registry *services.TenantRegistry
+}
+
+func NewTenantMiddleware(registry *services.TenantRegistry) *TenantMiddleware {
+	return &TenantMiddleware{
+		registry: registry,
+	}
+}
+
+// Handler determina el CUIT con el que se opera en la solicitud: el indicado en el header x-cuit,
+// o bien el único CUIT habilitado para la API Key o configurado en el servidor.
+func (m *TenantMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant, err := m.resolve(r)
+		if err != nil {
+			status := http.StatusForbidden
+			if errors.Is(err, ErrNoTenant) {
+				status = http.StatusBadRequest
+			}
+			util.HttpResponseJSON(w, status, &dto.ErrorResponse{Error: err.Error()}, err)
+			return
+		}
+		w.Header().Set("x-cuit", strconv.FormatInt(tenant.Cuit, 10))
+		next.ServeHTTP(w, r.WithContext(services.ContextWithTenant(r.Context(), tenant)))
+	})
+}
+
+func (m *TenantMiddleware) resolve(r *http.Request) (*services.Tenant, error) {
+	allowed := AllowedCuits(r)
+
+	header := strings.TrimSpace(r.Header.Get("x-cuit"))
+	if header == "" {
+		switch {
+		case len(allowed) == 1:
+			header = strconv.FormatInt(allowed[0], 10)
+		case len(allowed) == 0 && len(m.registry.All()) == 1:
+			return m.registry.All()[0], nil
+		default:
+			return nil, ErrNoTenant
+		}
+	}
+
+	cuit, err := strconv.ParseInt(header, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidTenant
+	}
+	if len(allowed) > 0 && !slices.Contains(allowed, cuit) {
+		return nil, ErrInvalidTenant
+	}
+	tenant, exist := m.registry.Get(cuit)
+	if !exist {
+		return nil, ErrInvalidTenant
+	}
+	return tenant, nil
+}
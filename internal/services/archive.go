@@ -0,0 +1,425 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ArchiveEntry es un intercambio con ARCA registrado en el archivo fiscal. Hash es el SHA-256 de la entrada
+// (sin Hash ni Signature), que incluye el Hash de la anterior en PrevHash: modificar, quitar o reordenar una
+// entrada rompe la cadena. Signature es la firma de Hash con la clave del CUIT, cuyo certificado se identifica
+// por su huella SHA-256 en Certificate. Con Encrypted, Request y Response son el intercambio completo cifrado
+// con AES-256-GCM y codificado en base64 (ver Decrypt).
+type ArchiveEntry struct {
+	Seq         uint64    `json:"seq"`
+	Time        time.Time `json:"time"`
+	RequestID   string    `json:"requestId"`
+	Cuit        int64     `json:"cuit"`
+	Service     string    `json:"service"`
+	Operation   string    `json:"operation"`
+	Attempt     int       `json:"attempt"`
+	URL         string    `json:"url"`
+	StatusCode  int       `json:"statusCode,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Encrypted   bool      `json:"encrypted,omitempty"`
+	Request     string    `json:"request"`
+	Response    string    `json:"response,omitempty"`
+	PrevHash    string    `json:"prevHash"`
+	Certificate string    `json:"certificate,omitempty"`
+	Hash        string    `json:"hash"`
+	Signature   []byte    `json:"signature,omitempty"`
+}
+
+// computeHash devuelve el SHA-256 de la entrada sin Hash ni Signature.
+func (e ArchiveEntry) computeHash() (string, error) {
+	e.Hash = ""
+	e.Signature = nil
+	data, err := e.marshal()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// marshal codifica la entrada en una línea JSON sin escapar el XML (<, > y &), para que el archivo sea legible.
+func (e ArchiveEntry) marshal() ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(e); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Decrypt reemplaza Request y Response de una entrada cifrada por el intercambio en claro.
+func (e *ArchiveEntry) Decrypt(key []byte) error {
+	if !e.Encrypted {
+		return nil
+	}
+	for _, body := range []*string{&e.Request, &e.Response} {
+		if *body == "" {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(*body)
+		if err != nil {
+			return fmt.Errorf("Decrypt: %s", err)
+		}
+		if data, err = DecryptAuditObject(key, data); err != nil {
+			return err
+		}
+		*body = string(data)
+	}
+	e.Encrypted = false
+	return nil
+}
+
+// ArchiveOptions configuran el archivo fiscal.
+type ArchiveOptions struct {
+	// Sign firma cada entrada con la clave del CUIT.
+	Sign bool
+	// Key guarda la solicitud y la respuesta completas (solo se ocultan el Token y el Sign), cifradas con
+	// AES-256-GCM con esta clave de 32 bytes. Vacía, se guardan en claro con las reglas de SetRedactionRules.
+	Key []byte
+}
+
+// Archive registra los intercambios con ARCA en un archivo JSONL de solo agregado por CUIT
+// (<dir>/<CUIT>.jsonl), encadenados por hash y opcionalmente firmados con el certificado del CUIT.
+type Archive struct {
+	dir     string
+	options ArchiveOptions
+	tenants map[int64]*Tenant
+
+	// mu protege chains; cada cadena tiene su propio mutex, de modo que la firma y el fsync de un CUIT no
+	// demoran a los demás.
+	mu     sync.Mutex
+	chains map[int64]*archiveChain
+}
+
+type archiveChain struct {
+	mu       sync.Mutex
+	file     *os.File
+	seq      uint64
+	lastHash string
+
+	signer      crypto.Signer
+	certificate string
+	loaded      time.Time
+}
+
+// NewArchive crea el archivo fiscal en dir para los tenants.
+func NewArchive(dir string, tenants []*Tenant, options ArchiveOptions) (*Archive, error) {
+	if len(options.Key) > 0 && len(options.Key) != 32 {
+		return nil, errors.New("NewArchive: la clave de cifrado debe tener 32 bytes")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("NewArchive: %s", err)
+	}
+	a := &Archive{
+		dir:     dir,
+		options: options,
+		tenants: make(map[int64]*Tenant),
+		chains:  make(map[int64]*archiveChain),
+	}
+	for _, tenant := range tenants {
+		a.tenants[tenant.Cuit] = tenant
+	}
+	return a, nil
+}
+
+// Record agrega el intercambio al archivo del CUIT. Se registra como CaptureHook con AddCaptureHook.
+func (a *Archive) Record(exchange *Exchange) {
+	if err := a.append(exchange); err != nil {
+		log.Printf("Error registrando el intercambio %s.%s de la solicitud %s en el archivo fiscal: %s\n", exchange.ServiceName, exchange.Operation, exchange.RequestID, err)
+	}
+}
+
+func (a *Archive) append(exchange *Exchange) error {
+	a.mu.Lock()
+	chain, err := a.chain(exchange.Cuit)
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	request, response, err := a.bodies(exchange)
+	if err != nil {
+		return err
+	}
+
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+	if chain.file == nil {
+		return errors.New("archivo fiscal cerrado")
+	}
+
+	entry := ArchiveEntry{
+		Seq:        chain.seq + 1,
+		Time:       exchange.StartedAt.UTC(),
+		RequestID:  exchange.RequestID,
+		Cuit:       exchange.Cuit,
+		Service:    exchange.ServiceName,
+		Operation:  exchange.Operation,
+		Attempt:    exchange.Attempt,
+		URL:        exchange.URL,
+		StatusCode: exchange.StatusCode,
+		Error:      exchange.Err,
+		Encrypted:  len(a.options.Key) > 0,
+		Request:    request,
+		Response:   response,
+		PrevHash:   chain.lastHash,
+	}
+	if a.options.Sign {
+		if err := a.loadSigner(chain, exchange.Cuit); err != nil {
+			return err
+		}
+		entry.Certificate = chain.certificate
+	}
+	if entry.Hash, err = entry.computeHash(); err != nil {
+		return err
+	}
+	if a.options.Sign {
+		digest, _ := hex.DecodeString(entry.Hash)
+		if entry.Signature, err = chain.signer.Sign(rand.Reader, digest, crypto.SHA256); err != nil {
+			return fmt.Errorf("firma: %s", err)
+		}
+	}
+
+	line, err := entry.marshal()
+	if err != nil {
+		return err
+	}
+	if _, err := chain.file.Write(line); err != nil {
+		return err
+	}
+	if err := chain.file.Sync(); err != nil {
+		return err
+	}
+	chain.seq = entry.Seq
+	chain.lastHash = entry.Hash
+	return nil
+}
+
+// archiveCredentialsPatterns ocultan las credenciales en los intercambios cifrados, que conservan el resto del
+// comprobante tal como se envió.
+var archiveCredentialsPatterns = compileRedactionRules(DefaultRedactionRules)
+
+// bodies devuelve la solicitud y la respuesta tal como se registran. En claro se aplican las reglas de
+// SetRedactionRules y los bytes que no son UTF-8 válido se reemplazan por U+FFFD antes de calcular el hash, de
+// modo que el texto firmado sea el mismo que se lee al verificar sin depender de cómo el codificador JSON trate
+// esos bytes. Cifrados se conservan los bytes exactos.
+func (a *Archive) bodies(exchange *Exchange) (request, response string, err error) {
+	if len(a.options.Key) == 0 {
+		return strings.ToValidUTF8(string(redactXML(exchange.RequestBody)), "\uFFFD"),
+			strings.ToValidUTF8(string(redactXML(exchange.ResponseBody)), "\uFFFD"), nil
+	}
+	encrypt := func(body []byte) (string, error) {
+		if len(body) == 0 {
+			return "", nil
+		}
+		data, err := encryptAuditObject(a.options.Key, redactXMLWith(archiveCredentialsPatterns, body))
+		if err != nil {
+			return "", fmt.Errorf("cifrado: %s", err)
+		}
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+	if request, err = encrypt(exchange.RequestBody); err != nil {
+		return "", "", err
+	}
+	if response, err = encrypt(exchange.ResponseBody); err != nil {
+		return "", "", err
+	}
+	return request, response, nil
+}
+
+// chain abre el archivo del CUIT y continúa la cadena desde su última entrada. Debe llamarse con a.mu tomado.
+func (a *Archive) chain(cuit int64) (*archiveChain, error) {
+	if chain, exist := a.chains[cuit]; exist {
+		return chain, nil
+	}
+
+	fileName := filepath.Join(a.dir, strconv.FormatInt(cuit, 10)+".jsonl")
+	f, err := os.OpenFile(fileName, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return nil, err
+	}
+	chain := &archiveChain{file: f}
+
+	// Se continúa desde la última entrada legible. Si la última quedó incompleta (p. ej. por una caída) no se
+	// modifica: se continúa en una línea nueva y la verificación la informa.
+	var last, previous []byte
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			previous, last = last, append([]byte(nil), scanner.Bytes()...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %s", fileName, err)
+	}
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		tail := make([]byte, 1)
+		if _, err := f.ReadAt(tail, info.Size()-1); err == nil && tail[0] != '\n' {
+			if _, err := f.Write([]byte{'\n'}); err != nil {
+				f.Close()
+				return nil, err
+			}
+		}
+	}
+	if last != nil {
+		var entry ArchiveEntry
+		if err := json.Unmarshal(last, &entry); err != nil {
+			log.Printf("ATENCIÓN: la última entrada del archivo fiscal %s está incompleta\n", fileName)
+			if previous != nil {
+				if err := json.Unmarshal(previous, &entry); err != nil {
+					f.Close()
+					return nil, fmt.Errorf("%s: no se puede continuar la cadena: %s", fileName, err)
+				}
+			}
+		}
+		chain.seq = entry.Seq
+		chain.lastHash = entry.Hash
+	}
+	a.chains[cuit] = chain
+	return chain, nil
+}
+
+// loadSigner lee la clave y el certificado del CUIT; se vuelven a leer cada hora para tomar un certificado renovado.
+func (a *Archive) loadSigner(chain *archiveChain, cuit int64) error {
+	if chain.signer != nil && time.Since(chain.loaded) < time.Hour {
+		return nil
+	}
+	tenant, exist := a.tenants[cuit]
+	if !exist {
+		return fmt.Errorf("CUIT %d no configurado", cuit)
+	}
+	signer, certificate, err := tenant.KeyPair()
+	if err != nil {
+		return err
+	}
+	chain.signer = signer
+	chain.certificate = certificateFingerprint(certificate)
+	chain.loaded = time.Now()
+	return nil
+}
+
+func certificateFingerprint(certificate *x509.Certificate) string {
+	sum := sha256.Sum256(certificate.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// Close cierra los archivos abiertos, después de que terminen las entradas en curso.
+func (a *Archive) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for cuit, chain := range a.chains {
+		chain.mu.Lock()
+		chain.file.Close()
+		chain.file = nil
+		chain.mu.Unlock()
+		delete(a.chains, cuit)
+	}
+}
+
+// ArchiveReport es el resultado de VerifyArchive.
+type ArchiveReport struct {
+	Entries  uint64
+	Signed   uint64
+	LastSeq  uint64
+	LastHash string
+	// Problems describe cada entrada ilegible, fuera de secuencia, modificada o con firma inválida.
+	Problems []string
+}
+
+// VerifyArchive recorre un archivo fiscal y verifica la secuencia, la cadena de hashes y, si se indican los
+// certificados, las firmas. Una entrada firmada con un certificado que no está en certificates se informa
+// como problema. El truncamiento al final del archivo solo se detecta comparando LastSeq y LastHash con
+// un valor conservado por separado.
+func VerifyArchive(r io.Reader, certificates []*x509.Certificate) (*ArchiveReport, error) {
+	publicKeys := make(map[string]crypto.PublicKey)
+	for _, certificate := range certificates {
+		publicKeys[certificateFingerprint(certificate)] = certificate.PublicKey
+	}
+
+	report := &ArchiveReport{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		problem := func(format string, args ...any) {
+			report.Problems = append(report.Problems, fmt.Sprintf("línea %d: ", line)+fmt.Sprintf(format, args...))
+		}
+
+		var entry ArchiveEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			problem("entrada ilegible: %s", err)
+			continue
+		}
+		report.Entries++
+
+		if entry.Seq != report.LastSeq+1 {
+			problem("secuencia %d, se esperaba %d (faltan o sobran entradas)", entry.Seq, report.LastSeq+1)
+		}
+		if entry.PrevHash != report.LastHash {
+			problem("secuencia %d: prevHash no coincide con el hash de la entrada anterior", entry.Seq)
+		}
+		hash, err := entry.computeHash()
+		if err != nil || hash != entry.Hash {
+			problem("secuencia %d: el hash no corresponde al contenido (entrada modificada)", entry.Seq)
+		}
+		if len(entry.Signature) > 0 {
+			report.Signed++
+			if len(certificates) > 0 {
+				publicKey, exist := publicKeys[entry.Certificate]
+				digest, _ := hex.DecodeString(entry.Hash)
+				if !exist {
+					problem("secuencia %d: firmada con un certificado desconocido [%s]", entry.Seq, entry.Certificate)
+				} else if err := verifySignature(publicKey, digest, entry.Signature); err != nil {
+					problem("secuencia %d: firma inválida", entry.Seq)
+				}
+			}
+		}
+
+		report.LastSeq = entry.Seq
+		report.LastHash = entry.Hash
+	}
+	return report, scanner.Err()
+}
+
+func verifySignature(publicKey crypto.PublicKey, digest, signature []byte) error {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("firma ECDSA inválida")
+		}
+		return nil
+	default:
+		return fmt.Errorf("tipo de clave no soportado %T", publicKey)
+	}
+}
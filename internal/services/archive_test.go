@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestArchiveRoundTrip registra intercambios concurrentes de dos CUIT, con cuerpos que no son UTF-8 válido, y
+// verifica que la cadena de cada archivo se verifica sin problemas y que el contenido se oculta o se cifra.
+func TestArchiveRoundTrip(t *testing.T) {
+	if err := SetRedactionRules([]RedactionRule{{Element: "DocNro", Keep: 4}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetRedactionRules(nil) })
+
+	key := bytes.Repeat([]byte{7}, 32)
+	tenants := []*Tenant{testTenant(t, 20111111112), testTenant(t, 20222222223)}
+	var certificates []*x509.Certificate
+	for _, tenant := range tenants {
+		_, certificate, err := tenant.KeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	request := []byte("<Auth><Token>secreto</Token><Sign>firma</Sign></Auth><DocNro>20333333334</DocNro><Obs>a\xff\xfeb</Obs>")
+	response := []byte("<CAE>76123456789012</CAE><Obs>\xc3(</Obs>")
+
+	tests := []struct {
+		name    string
+		options ArchiveOptions
+	}{
+		{name: "en claro", options: ArchiveOptions{}},
+		{name: "firmado", options: ArchiveOptions{Sign: true}},
+		{name: "cifrado", options: ArchiveOptions{Key: key}},
+		{name: "cifrado y firmado", options: ArchiveOptions{Sign: true, Key: key}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			archive, err := NewArchive(dir, tenants, test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var wg sync.WaitGroup
+			for i := range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					exchange := testExchange(tenants[i%2].Cuit)
+					exchange.RequestBody = request
+					exchange.ResponseBody = response
+					archive.Record(exchange)
+				}()
+			}
+			wg.Wait()
+			archive.Close()
+
+			for _, tenant := range tenants {
+				data, err := os.ReadFile(filepath.Join(dir, strconv.FormatInt(tenant.Cuit, 10)+".jsonl"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				report, err := VerifyArchive(bytes.NewReader(data), certificates)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if report.Entries != 10 || report.LastSeq != 10 || len(report.Problems) > 0 {
+					t.Errorf("CUIT %d: %d entradas hasta la secuencia %d, problemas %v", tenant.Cuit, report.Entries, report.LastSeq, report.Problems)
+				}
+				if signed := report.Signed == report.Entries; signed != test.options.Sign {
+					t.Errorf("CUIT %d: %d de %d entradas firmadas", tenant.Cuit, report.Signed, report.Entries)
+				}
+				if bytes.Contains(data, []byte("secreto")) || bytes.Contains(data, []byte("20333333334")) {
+					t.Errorf("CUIT %d: el archivo contiene datos ocultos:\n%s", tenant.Cuit, data)
+				}
+
+				var entry ArchiveEntry
+				if err := json.Unmarshal(data[:bytes.IndexByte(data, '\n')], &entry); err != nil {
+					t.Fatal(err)
+				}
+				if entry.Encrypted != (test.options.Key != nil) {
+					t.Errorf("CUIT %d: Encrypted = %v", tenant.Cuit, entry.Encrypted)
+				}
+				if err := entry.Decrypt(key); err != nil {
+					t.Fatal(err)
+				}
+				wantRequest := "<Auth><Token>***</Token><Sign>***</Sign></Auth><DocNro>*******3334</DocNro><Obs>a�b</Obs>"
+				wantResponse := "<CAE>76123456789012</CAE><Obs>�(</Obs>"
+				if test.options.Key != nil {
+					// Cifrado se conserva el intercambio completo, salvo las credenciales.
+					wantRequest = "<Auth><Token>***</Token><Sign>***</Sign></Auth><DocNro>20333333334</DocNro><Obs>a\xff\xfeb</Obs>"
+					wantResponse = string(response)
+				}
+				if entry.Request != wantRequest || entry.Response != wantResponse {
+					t.Errorf("CUIT %d: entrada = %q / %q, se esperaba %q / %q", tenant.Cuit, entry.Request, entry.Response, wantRequest, wantResponse)
+				}
+			}
+		})
+	}
+
+	t.Run("modificado", func(t *testing.T) {
+		dir := t.TempDir()
+		archive, err := NewArchive(dir, tenants, ArchiveOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		archive.Record(testExchange(tenants[0].Cuit))
+		archive.Close()
+		data, err := os.ReadFile(filepath.Join(dir, strconv.FormatInt(tenants[0].Cuit, 10)+".jsonl"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = bytes.Replace(data, []byte("FECAESolicitar"), []byte("FECAEConsultar"), 1)
+		report, err := VerifyArchive(bytes.NewReader(data), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Problems) != 1 || !strings.Contains(report.Problems[0], "modificada") {
+			t.Errorf("problemas = %v, se esperaba la entrada modificada", report.Problems)
+		}
+	})
+}
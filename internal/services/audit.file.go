@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileAuditSink guarda los intercambios en un directorio local.
+type FileAuditSink struct {
+	dir string
+}
+
+func NewFileAuditSink(dir string) (*FileAuditSink, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("NewFileAuditSink: %s", err)
+	}
+	return &FileAuditSink{dir: dir}, nil
+}
+
+func (s *FileAuditSink) path(name string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(name))
+	if !strings.HasPrefix(path, filepath.Clean(s.dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("FileAuditSink: nombre inválido [%s]", name)
+	}
+	return path, nil
+}
+
+// Put escribe el objeto en un archivo temporal y lo enlaza con su nombre definitivo, de modo que nunca
+// se lee un archivo incompleto ni se reemplaza uno existente.
+func (s *FileAuditSink) Put(ctx context.Context, name string, data []byte) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".audit-*")
+	if err != nil {
+		return err
+	}
+	tmpName := f.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Link(tmpName, path)
+}
+
+func (s *FileAuditSink) List(ctx context.Context) ([]AuditObject, error) {
+	var objects []AuditObject
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		name, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, AuditObject{Name: filepath.ToSlash(name), Size: info.Size(), ModTime: info.ModTime()})
+		return ctx.Err()
+	})
+	return objects, err
+}
+
+// Delete elimina el objeto y los directorios que quedan vacíos.
+func (s *FileAuditSink) Delete(ctx context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	root := filepath.Clean(s.dir)
+	for dir := filepath.Dir(path); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
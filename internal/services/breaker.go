@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Estados del circuit breaker de un endpoint de ARCA.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// BreakerPolicy define cuándo se abre el circuito de un endpoint y cuánto permanece abierto.
+type BreakerPolicy struct {
+	// FailureThreshold es la cantidad de fallas consecutivas que abren el circuito (0 lo desactiva).
+	FailureThreshold int
+	// OpenTimeout es el tiempo que el circuito permanece abierto antes de probar el endpoint con Dummy.
+	OpenTimeout time.Duration
+}
+
+// DefaultBreakerPolicy es la política utilizada si no se llama a SetBreakerPolicy.
+var DefaultBreakerPolicy = BreakerPolicy{FailureThreshold: 5, OpenTimeout: 30 * time.Second}
+
+var (
+	breakersMu    sync.Mutex
+	breakers      = make(map[string]*circuitBreaker)
+	breakerPolicy = DefaultBreakerPolicy
+)
+
+// SetBreakerPolicy define la política de los circuit breakers de los endpoints de ARCA.
+func SetBreakerPolicy(policy BreakerPolicy) {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	breakerPolicy = policy
+}
+
+// BreakerStatus es el estado del circuit breaker de un endpoint.
+type BreakerStatus struct {
+	URL       string    `json:"url"`
+	State     string    `json:"state"`
+	Failures  int       `json:"failures"`
+	OpenedAt  time.Time `json:"openedAt,omitzero"`
+	RetryAt   time.Time `json:"retryAt,omitzero"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+// Breakers devuelve el estado de los circuit breakers, ordenados por URL.
+func Breakers() []BreakerStatus {
+	breakersMu.Lock()
+	urls := slices.Sorted(maps.Keys(breakers))
+	list := make([]*circuitBreaker, 0, len(urls))
+	for _, url := range urls {
+		list = append(list, breakers[url])
+	}
+	breakersMu.Unlock()
+
+	result := make([]BreakerStatus, 0, len(list))
+	for _, breaker := range list {
+		result = append(result, breaker.status())
+	}
+	return result
+}
+
+// CircuitOpenError indica que no se llamó a ARCA porque el circuito del endpoint está abierto.
+type CircuitOpenError struct {
+	URL        string
+	RetryAfter time.Duration
+	Err        string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("el servicio de ARCA [%s] no está disponible (circuito abierto, reintente en %s): %s", e.URL, e.RetryAfter.Round(time.Second), e.Err)
+}
+
+// circuitBreaker deja de llamar a un endpoint después de varias fallas consecutivas. Pasado OpenTimeout,
+// la primera solicitud prueba el endpoint con la operación Dummy del servicio: si responde, el circuito se
+// cierra; si no, vuelve a abrirse.
+type circuitBreaker struct {
+	url   string
+	probe func(ctx context.Context) error
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	openedAt  time.Time
+	retryAt   time.Time
+	lastError string
+}
+
+// breakerFor devuelve el circuit breaker del endpoint, compartido por todos los tenants. probe es la llamada
+// a Dummy con la que se prueba el endpoint en estado half-open; recibe un cliente HTTP propio del circuit breaker,
+// sin captura ni datos de ningún tenant, para que el estado no dependa del tenant que creó el circuit breaker.
+// Las fallas que se registran son de transporte o HTTP, que tampoco dependen de las credenciales.
+func breakerFor(url string, environment Environment, probe func(ctx context.Context, client *http.Client) error) *circuitBreaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	breaker, exist := breakers[url]
+	if !exist {
+		client := &http.Client{Timeout: 90 * time.Second, Transport: &keepAliveTransport{base: arcaTransport(environment)}}
+		breaker = &circuitBreaker{url: url, state: BreakerClosed, probe: func(ctx context.Context) error {
+			return probe(ctx, client)
+		}}
+		breakers[url] = breaker
+	}
+	return breaker
+}
+
+func currentBreakerPolicy() BreakerPolicy {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	return breakerPolicy
+}
+
+func (b *circuitBreaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := BreakerStatus{URL: b.url, State: b.state, Failures: b.failures, LastError: b.lastError}
+	if b.state != BreakerClosed {
+		status.OpenedAt, status.RetryAt = b.openedAt, b.retryAt
+	}
+	return status
+}
+
+// allow indica si se puede llamar al endpoint. Si el circuito está abierto y venció OpenTimeout,
+// prueba el endpoint con Dummy antes de permitir la llamada.
+func (b *circuitBreaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+	switch {
+	case b.state == BreakerClosed:
+		b.mu.Unlock()
+		return nil
+	case b.state == BreakerHalfOpen || time.Now().Before(b.retryAt):
+		err := &CircuitOpenError{URL: b.url, RetryAfter: max(time.Until(b.retryAt), time.Second), Err: b.lastError}
+		b.mu.Unlock()
+		return err
+	}
+	b.state = BreakerHalfOpen
+	b.mu.Unlock()
+
+	log.Printf("Probando el servicio de ARCA [%s] con Dummy...\n", b.url)
+	err := b.probe(ctx)
+	if errors.Is(err, context.Canceled) {
+		// El cliente canceló la solicitud durante la prueba: la próxima solicitud vuelve a probar.
+		b.mu.Lock()
+		b.state = BreakerOpen
+		b.mu.Unlock()
+		return err
+	}
+	b.record(err)
+	if err != nil {
+		return b.allow(ctx)
+	}
+	return nil
+}
+
+// record registra el resultado de una llamada al endpoint.
+func (b *circuitBreaker) record(err error) {
+	policy := currentBreakerPolicy()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		if b.state != BreakerClosed {
+			log.Printf("El servicio de ARCA [%s] volvió a responder; se cierra el circuito\n", b.url)
+		}
+		b.state, b.failures, b.lastError = BreakerClosed, 0, ""
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	if b.state == BreakerHalfOpen || (policy.FailureThreshold > 0 && b.failures >= policy.FailureThreshold) {
+		if b.state == BreakerClosed {
+			log.Printf("El servicio de ARCA [%s] falló %d veces consecutivas; se abre el circuito por %s: %s\n", b.url, b.failures, policy.OpenTimeout, err)
+			b.openedAt = time.Now()
+		}
+		b.state = BreakerOpen
+		b.retryAt = time.Now().Add(policy.OpenTimeout)
+	}
+}
+
+// breakerTransport corta las llamadas al endpoint mientras su circuito está abierto y registra el
+// resultado de las demás. Una llamada con reintentos cuenta como una sola.
+type breakerTransport struct {
+	breaker *circuitBreaker
+	base    http.RoundTripper
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.allow(req.Context()); err != nil {
+		return nil, err
+	}
+
+	res, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(err, context.Canceled):
+		// El cliente canceló la solicitud: no es una falla del endpoint.
+	case err != nil:
+		t.breaker.record(err)
+	default:
+		var failure bool
+		res, failure = transientResponse(res, true)
+		if failure {
+			t.breaker.record(fmt.Errorf("HTTP %d", res.StatusCode))
+		} else {
+			t.breaker.record(nil)
+		}
+	}
+	return res, err
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestBreakerProbeWithoutTenant verifica que el circuit breaker de un endpoint compartido prueba el endpoint
+// con su propio cliente, sin pasar por la captura ni el CUIT del tenant que lo creó.
+func TestBreakerProbeWithoutTenant(t *testing.T) {
+	fake := newFakeArca(t)
+	fake.down.Store(true)
+	SetRetryPolicy(RetryPolicy{})
+	t.Cleanup(func() { SetRetryPolicy(DefaultRetryPolicy) })
+	SetBreakerPolicy(BreakerPolicy{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+	t.Cleanup(func() { SetBreakerPolicy(DefaultBreakerPolicy) })
+
+	var mu sync.Mutex
+	var cuits []int64
+	captureHooksMu.Lock()
+	previous := captureHooks
+	captureHooksMu.Unlock()
+	AddCaptureHook(func(exchange *Exchange) {
+		if exchange.URL != fake.server.URL {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		cuits = append(cuits, exchange.Cuit)
+	})
+	t.Cleanup(func() {
+		captureHooksMu.Lock()
+		captureHooks = previous
+		captureHooksMu.Unlock()
+	})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	first, err := NewWsfe(logger, TESTING, &Tenant{Cuit: 20111111112}, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewWsfe(logger, TESTING, &Tenant{Cuit: 20222222223}, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := first.FEDummy(context.Background()); err == nil {
+		t.Fatal("FEDummy() con el servicio caído no devolvió error")
+	}
+	fake.down.Store(false)
+	time.Sleep(5 * time.Millisecond)
+	if _, err := second.FEDummy(context.Background()); err != nil {
+		t.Fatalf("FEDummy() después de la prueba del circuit breaker = %s", err)
+	}
+
+	if n := fake.calls.Load(); n != 3 {
+		t.Errorf("solicitudes = %d, se esperaban 3 (la falla, la prueba y la llamada)", n)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []int64{20111111112, 20222222223}; !slices.Equal(cuits, want) {
+		t.Errorf("intercambios capturados de los CUIT %v, se esperaba %v sin la prueba", cuits, want)
+	}
+}
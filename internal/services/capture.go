@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exchange es una llamada HTTP a un servicio de ARCA tal como viajó: el sobre SOAP enviado y recibido,
+// con sus headers y el código de estado. Cada reintento es un Exchange distinto.
+type Exchange struct {
+	RequestID      string
+	ServiceName    string
+	Operation      string
+	Cuit           int64
+	Attempt        int
+	Method         string
+	URL            string
+	StartedAt      time.Time
+	Duration       time.Duration
+	RequestHeader  http.Header
+	RequestBody    []byte
+	StatusCode     int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+	Err            string
+}
+
+// Dump devuelve el intercambio en formato de mensaje HTTP: la solicitud y la respuesta con sus headers y cuerpos.
+func (e *Exchange) Dump() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s\r\n", e.Method, e.URL)
+	writeHeader(&b, e.RequestHeader)
+	b.WriteString("\r\n")
+	b.Write(e.RequestBody)
+	b.WriteString("\r\n\r\n")
+	if e.Err != "" {
+		fmt.Fprintf(&b, "ERROR %s\r\n", e.Err)
+		return b.Bytes()
+	}
+	fmt.Fprintf(&b, "HTTP %d %s\r\n", e.StatusCode, http.StatusText(e.StatusCode))
+	writeHeader(&b, e.ResponseHeader)
+	b.WriteString("\r\n")
+	b.Write(e.ResponseBody)
+	return b.Bytes()
+}
+
+func writeHeader(w io.Writer, header http.Header) {
+	for _, key := range slices.Sorted(maps.Keys(header)) {
+		for _, value := range header[key] {
+			fmt.Fprintf(w, "%s: %s\r\n", key, value)
+		}
+	}
+}
+
+// CaptureHook recibe cada intercambio con ARCA. Se llama de forma sincrónica: no debe demorar la operación.
+type CaptureHook func(*Exchange)
+
+var (
+	captureHooksMu sync.RWMutex
+	captureHooks   []CaptureHook
+)
+
+// AddCaptureHook registra una función que recibe todos los intercambios con los servicios de ARCA, sin ocultar
+// las credenciales ni los datos personales.
+func AddCaptureHook(hook CaptureHook) {
+	captureHooksMu.Lock()
+	defer captureHooksMu.Unlock()
+	captureHooks = append(captureHooks, hook)
+}
+
+type requestIDContextKey struct{}
+
+// NewRequestID genera un identificador de solicitud aleatorio.
+func NewRequestID() string {
+	id := make([]byte, 12)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// ContextWithRequestID asocia a ctx el identificador de la solicitud a la API.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext devuelve el identificador de la solicitud asociado a ctx.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// captureTransport registra cada intercambio HTTP con ARCA con los bytes exactos enviados y recibidos, y lo
+// entrega a capture y a los hooks registrados con AddCaptureHook.
+type captureTransport struct {
+	serviceName string
+	cuit        int64
+	// operation se registra cuando el SOAPAction no identifica la operación, como en WSAA.
+	operation string
+	capture   CaptureHook
+	base      http.RoundTripper
+}
+
+func (t *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	info, _ := req.Context().Value(operationContextKey{}).(*operationInfo)
+	if info == nil {
+		info = &operationInfo{serviceName: t.serviceName, requestID: NewRequestID()}
+	}
+
+	exchange := &Exchange{
+		RequestID:     info.requestID,
+		ServiceName:   t.serviceName,
+		Operation:     cmp.Or(t.operation, soapOperation(req)),
+		Cuit:          t.cuit,
+		Attempt:       int(info.attempts.Add(1)),
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		StartedAt:     time.Now(),
+		RequestHeader: req.Header.Clone(),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			exchange.RequestBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	res, err := t.base.RoundTrip(req)
+	exchange.Duration = time.Since(exchange.StartedAt)
+	if err != nil {
+		exchange.Err = err.Error()
+	} else {
+		exchange.StatusCode = res.StatusCode
+		exchange.ResponseHeader = res.Header.Clone()
+		exchange.ResponseBody, err = io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(exchange.ResponseBody))
+		if err != nil {
+			exchange.Err = err.Error()
+			res = nil
+		}
+	}
+	t.notify(exchange)
+	return res, err
+}
+
+func (t *captureTransport) notify(exchange *Exchange) {
+	if t.capture != nil {
+		t.capture(exchange)
+	}
+	captureHooksMu.RLock()
+	hooks := captureHooks
+	captureHooksMu.RUnlock()
+	for _, hook := range hooks {
+		hook(exchange)
+	}
+}
+
+// exchangeFileName devuelve un nombre de archivo único para el intercambio: fecha, solicitud, operación e intento.
+func exchangeFileName(exchange *Exchange) string {
+	timestamp := strings.ReplaceAll(exchange.StartedAt.Format("20060102T150405.000"), ".", "")
+	name := fmt.Sprintf("%s_%s_%s", timestamp, exchange.RequestID, exchange.Operation)
+	if exchange.Attempt > 1 {
+		name = fmt.Sprintf("%s_%d", name, exchange.Attempt)
+	}
+	return name
+}
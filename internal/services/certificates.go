@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sehogas/goarca/internal/util"
+)
+
+// Estados del certificado de un tenant.
+const (
+	CertificateValid   = "valid"
+	CertificateWarning = "warning"
+	CertificateExpired = "expired"
+	CertificateError   = "error"
+)
+
+// CertificateStatus es el estado del certificado ARCA de un tenant.
+type CertificateStatus struct {
+	Cuit         int64     `json:"cuit"`
+	Subject      string    `json:"subject,omitempty"`
+	Issuer       string    `json:"issuer,omitempty"`
+	SerialNumber string    `json:"serialNumber,omitempty"`
+	NotBefore    time.Time `json:"notBefore,omitzero"`
+	NotAfter     time.Time `json:"notAfter,omitzero"`
+	DaysLeft     int       `json:"daysLeft"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error,omitempty"`
+	CheckedAt    time.Time `json:"checkedAt"`
+}
+
+// CertificateMonitor revisa periódicamente el vencimiento de los certificados y registra una advertencia
+// cada vez que los días restantes cruzan uno de los umbrales configurados.
+type CertificateMonitor struct {
+	logger     *slog.Logger
+	tenants    []*Tenant
+	thresholds []int
+	interval   time.Duration
+
+	mu     sync.Mutex
+	status map[int64]*CertificateStatus
+	// warned es el menor umbral ya advertido por CUIT, para no repetir la advertencia en cada revisión.
+	warned map[int64]int
+}
+
+// NewCertificateMonitor crea un monitor para los tenants indicados. thresholds son los días
+// antes del vencimiento en los que se advierte (por ejemplo 30, 15 y 7).
+func NewCertificateMonitor(logger *slog.Logger, tenants []*Tenant, thresholds []int) *CertificateMonitor {
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	}
+	thresholds = slices.Clone(thresholds)
+	slices.Sort(thresholds)
+	slices.Reverse(thresholds)
+
+	m := &CertificateMonitor{
+		logger:     logger,
+		tenants:    tenants,
+		thresholds: thresholds,
+		interval:   6 * time.Hour,
+		status:     make(map[int64]*CertificateStatus, len(tenants)),
+		warned:     make(map[int64]int, len(tenants)),
+	}
+	m.Check()
+	return m
+}
+
+// Start revisa los certificados periódicamente hasta que ctx se cancele.
+func (m *CertificateMonitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Check()
+			}
+		}
+	}()
+}
+
+// Check lee nuevamente los certificados, de modo que se detecte uno renovado sin reiniciar.
+func (m *CertificateMonitor) Check() {
+	for _, tenant := range m.tenants {
+		status := certificateStatus(tenant, time.Now(), m.warningDays())
+		m.report(status)
+
+		m.mu.Lock()
+		m.status[tenant.Cuit] = status
+		m.mu.Unlock()
+	}
+}
+
+// Status devuelve el estado del certificado de cada tenant, ordenado por CUIT.
+func (m *CertificateMonitor) Status() []CertificateStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]CertificateStatus, 0, len(m.tenants))
+	for _, tenant := range m.tenants {
+		result = append(result, *m.status[tenant.Cuit])
+	}
+	return result
+}
+
+func (m *CertificateMonitor) warningDays() int {
+	if len(m.thresholds) == 0 {
+		return 0
+	}
+	return m.thresholds[0]
+}
+
+func (m *CertificateMonitor) report(status *CertificateStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch status.Status {
+	case CertificateError:
+		m.logger.Error("No se pudo verificar el certificado", "cuit", status.Cuit, "err", status.Error)
+	case CertificateExpired:
+		m.logger.Error("Certificado vencido", "cuit", status.Cuit, "notAfter", status.NotAfter, "err", status.Error)
+	default:
+		// Busco el menor umbral alcanzado; si ya se advirtió, no se repite.
+		reached := 0
+		for _, threshold := range m.thresholds {
+			if status.DaysLeft <= threshold {
+				reached = threshold
+			}
+		}
+		previous, warned := m.warned[status.Cuit]
+		switch {
+		case reached == 0:
+			delete(m.warned, status.Cuit)
+		case !warned || reached < previous:
+			m.warned[status.Cuit] = reached
+			m.logger.Warn("El certificado está próximo a vencer", "cuit", status.Cuit, "daysLeft", status.DaysLeft, "notAfter", status.NotAfter)
+		}
+	}
+}
+
+// certificateStatus lee y verifica el certificado del tenant.
+func certificateStatus(tenant *Tenant, now time.Time, warningDays int) *CertificateStatus {
+	status := &CertificateStatus{Cuit: tenant.Cuit, CheckedAt: now}
+
+	_, certificate, err := tenant.KeyPair()
+	if err != nil {
+		status.Status, status.Error = CertificateError, err.Error()
+		return status
+	}
+
+	status.Subject = certificate.Subject.String()
+	status.Issuer = certificate.Issuer.String()
+	status.SerialNumber = certificate.SerialNumber.Text(16)
+	status.NotBefore = certificate.NotBefore
+	status.NotAfter = certificate.NotAfter
+	status.DaysLeft = int(certificate.NotAfter.Sub(now).Hours() / 24)
+
+	switch err := util.CheckCertificate(certificate, tenant.Cuit, now); {
+	case err != nil && now.After(certificate.NotAfter):
+		status.Status, status.Error = CertificateExpired, err.Error()
+	case err != nil:
+		status.Status, status.Error = CertificateError, err.Error()
+	case status.DaysLeft <= warningDays:
+		status.Status = CertificateWarning
+	default:
+		status.Status = CertificateValid
+	}
+	return status
+}
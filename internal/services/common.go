@@ -1,15 +1,6 @@
 package services
 
-import (
-	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"strings"
-	"time"
-
-	"github.com/joho/godotenv"
-)
+import "context"
 
 const URLWSAATesting string = "https://wsaahomo.afip.gov.ar/ws/services/LoginCms?WSDL"
 const URLWSAAProduction string = "https://wsaa.afip.gov.ar/ws/services/LoginCms?WSDL"
@@ -33,34 +24,8 @@ const (
 	PRODUCTION
 )
 
-// func GenTA(environment Environment, serviceName string, cuit int64) error {
-// 	wsaa, err := NewWsaa(environment,
-// 		os.Getenv("PRIVATE_KEY_FILE"),
-// 		os.Getenv("CERTIFICATE_FILE"),
-// 		cuit)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	loginTicket, err := wsaa.GetLoginTicket(serviceName)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	os.Setenv("CUIT", strconv.FormatInt(loginTicket.Cuit, 10))
-// 	os.Setenv("TOKEN", loginTicket.Token)
-// 	os.Setenv("SIGN", loginTicket.Sign)
-// 	os.Setenv("EXPIRATION", loginTicket.ExpirationTime.Format(time.RFC3339))
-
-//		return nil
-//	}
-var tickets map[string]*LoginTicket
-
-func GenerarTA(environment Environment, serviceName string, cuit int64) (*LoginTicket, error) {
-	wsaa, err := NewWsaa(environment,
-		os.Getenv("PRIVATE_KEY_FILE"),
-		os.Getenv("CERTIFICATE_FILE"),
-		cuit)
+func GenerarTA(environment Environment, serviceName string, tenant *Tenant) (*LoginTicket, error) {
+	wsaa, err := NewWsaa(environment, tenant)
 	if err != nil {
 		return nil, err
 	}
@@ -68,80 +33,7 @@ func GenerarTA(environment Environment, serviceName string, cuit int64) (*LoginT
 	return wsaa.GetLoginTicket(serviceName)
 }
 
-func GrabarTA(serviceName string, ticket *LoginTicket) error {
-	fileName := fmt.Sprintf("data/%s.TA", serviceName)
-	prefix := strings.TrimSpace(strings.ToUpper(serviceName))
-	log.Printf("Almacenando ticket de acceso para  [%s] en [%s]...\n", serviceName, fileName)
-	data := fmt.Sprintf("%s_CUIT=%s\n%s_TOKEN=%s\n%s_SIGN=%s\n%s_EXPIRATION=%s\n",
-		prefix, strconv.FormatInt(ticket.Cuit, 10),
-		prefix, ticket.Token,
-		prefix, ticket.Sign,
-		prefix, ticket.ExpirationTime.Format(time.RFC3339))
-	f, err := os.Create(fileName)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	_, err = f.WriteString(data)
-	if err != nil {
-		return err
-	}
-	log.Printf("Ticket de acceso almacenado para [%s]\n", serviceName)
-	return nil
-}
-
-func GetTA(environment Environment, serviceName string, cuit int64) (*LoginTicket, error) {
-	if tickets == nil {
-		tickets = make(map[string]*LoginTicket)
-	}
-	ticketMemory, exist := tickets[serviceName]
-	if exist {
-		if ticketMemory.ExpirationTime.After(time.Now()) {
-			return ticketMemory, nil
-		}
-	}
-
-	var ticket *LoginTicket
-	err := godotenv.Load(fmt.Sprintf("data/%s.TA", serviceName))
-	if err != nil {
-		log.Printf("Intentando genera ticket de acceso para [%s]...\n", serviceName)
-		ticket, err = GenerarTA(environment, serviceName, cuit)
-		if err != nil {
-			return nil, err
-		}
-		log.Printf("Ticket de acceso emitido para [%s]\n", serviceName)
-		tickets[serviceName] = ticket
-		if err = GrabarTA(serviceName, ticket); err != nil {
-			return ticket, err
-		}
-	} else {
-		prefix := strings.TrimSpace(strings.ToUpper(serviceName))
-		expirationTime, err := time.Parse(time.RFC3339, os.Getenv(fmt.Sprintf("%s_EXPIRATION", prefix)))
-		if err != nil {
-			return nil, err
-		}
-		ticket = &LoginTicket{
-			ServiceName:    serviceName,
-			Token:          os.Getenv(fmt.Sprintf("%s_TOKEN", prefix)),
-			Sign:           os.Getenv(fmt.Sprintf("%s_SIGN", prefix)),
-			ExpirationTime: expirationTime,
-			Cuit:           cuit,
-		}
-	}
-
-	if ticket.ExpirationTime.Before(time.Now()) {
-		log.Printf("Renovando ticket de acceso para [%s]...\n", serviceName)
-		ticket, err = GenerarTA(environment, serviceName, cuit)
-		if err != nil {
-			return nil, err
-		}
-		log.Printf("Ticket de acceso emitido para [%s]\n", serviceName)
-		if err = GrabarTA(serviceName, ticket); err != nil {
-			tickets[serviceName] = ticket
-			return ticket, err
-		}
-	}
-
-	tickets[serviceName] = ticket
-	return ticket, nil
+// GetTA devuelve el ticket de acceso vigente del tenant para el servicio, utilizando el administrador de tickets por defecto.
+func GetTA(ctx context.Context, environment Environment, serviceName string, tenant *Tenant) (*LoginTicket, error) {
+	return defaultTicketManager.Get(ctx, environment, serviceName, tenant)
 }
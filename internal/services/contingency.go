@@ -0,0 +1,711 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sehogas/goarca/ws/wsfe"
+)
+
+// Estados de los comprobantes emitidos en contingencia.
+const (
+	ContingencyPending  = "pendiente"
+	ContingencyReported = "informado"
+	ContingencyRejected = "rechazado"
+)
+
+var (
+	ErrNoCAEA               = errors.New("no hay un CAEA vigente para la fecha del comprobante")
+	ErrContingencyPtoVta    = errors.New("el punto de venta no está habilitado para CAEA")
+	ErrContingencyNumbering = errors.New("no se registró el último comprobante autorizado del punto de venta y tipo para iniciar su numeración")
+
+	ErrContingencyInvoiceNotFound = errors.New("comprobante de contingencia inexistente")
+	ErrContingencyNotRejected     = errors.New("solo se pueden corregir los comprobantes de contingencia rechazados")
+)
+
+// caeaRequestDays son los días corridos previos al inicio de la quincena en los que ARCA permite solicitar el CAEA.
+const caeaRequestDays = 5
+
+// argentina es la zona horaria de las fechas de ARCA.
+var argentina = time.FixedZone("ART", -3*60*60)
+
+// CAEA es un Código de Autorización Electrónico Anticipado otorgado para una quincena. Las fechas tienen el
+// formato aaaammdd de ARCA.
+type CAEA struct {
+	Cuit        int64  `json:"cuit"`
+	CAEA        string `json:"caea"`
+	Periodo     int32  `json:"periodo"`
+	Orden       int16  `json:"orden"`
+	FchVigDesde string `json:"fchVigDesde"`
+	FchVigHasta string `json:"fchVigHasta"`
+	FchTopeInf  string `json:"fchTopeInf"`
+	FchProceso  string `json:"fchProceso,omitempty"`
+}
+
+// covers indica si la fecha (aaaammdd) está dentro de la vigencia del CAEA.
+func (c *CAEA) covers(date string) bool {
+	return date >= c.FchVigDesde && date <= c.FchVigHasta
+}
+
+// ContingencyInvoice es un comprobante emitido con CAEA y guardado localmente hasta informarlo a ARCA.
+type ContingencyInvoice struct {
+	ID            int64                  `json:"id"`
+	Cuit          int64                  `json:"cuit"`
+	CAEA          string                 `json:"caea"`
+	PtoVta        int32                  `json:"ptoVta"`
+	CbteTipo      int32                  `json:"cbteTipo"`
+	CbteNro       int64                  `json:"cbteNro"`
+	CbteFch       string                 `json:"cbteFch"`
+	Detalle       *wsfe.FECAEADetRequest `json:"detalle"`
+	Status        string                 `json:"status"`
+	Observaciones []ArcaMessage          `json:"observaciones,omitempty"`
+	Attempts      int                    `json:"attempts"`
+	LastError     string                 `json:"lastError,omitempty"`
+	CreatedAt     time.Time              `json:"created"`
+	ReportedAt    time.Time              `json:"reported,omitzero"`
+}
+
+// ContingencyStatus es el estado de la contingencia de un CUIT.
+type ContingencyStatus struct {
+	Cuit int64 `json:"cuit"`
+	// PtosVta son los puntos de venta habilitados para CAEA según ARCA (nil si aún no se pudieron consultar).
+	PtosVta   []int32   `json:"ptosVta"`
+	CAEAs     []*CAEA   `json:"caeas"`
+	Pending   int       `json:"pending"`
+	Rejected  int       `json:"rejected"`
+	LastRun   time.Time `json:"lastRun,omitzero"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+// fortnight es una quincena: periodo aaaamm y orden 1 (del 1 al 15) o 2 (del 16 a fin de mes).
+type fortnight struct {
+	periodo int32
+	orden   int16
+}
+
+func fortnightOf(t time.Time) fortnight {
+	t = t.In(argentina)
+	f := fortnight{periodo: int32(t.Year()*100 + int(t.Month())), orden: 1}
+	if t.Day() > 15 {
+		f.orden = 2
+	}
+	return f
+}
+
+func (f fortnight) start() time.Time {
+	day := 1
+	if f.orden == 2 {
+		day = 16
+	}
+	return time.Date(int(f.periodo/100), time.Month(f.periodo%100), day, 0, 0, 0, 0, argentina)
+}
+
+func (f fortnight) next() fortnight {
+	if f.orden == 1 {
+		return fortnight{periodo: f.periodo, orden: 2}
+	}
+	start := f.start()
+	return fortnightOf(time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, argentina))
+}
+
+type contingencyTenant struct {
+	ws *Wsfe
+	// ptosVta son los puntos de venta CAEA habilitados; nil hasta consultarlos a ARCA.
+	ptosVta   []int32
+	regXReq   int
+	lastRun   time.Time
+	lastError string
+}
+
+// ContingencyManager mantiene el CAEA de la quincena actual y de la siguiente, numera y guarda los comprobantes
+// emitidos con CAEA mientras wsfev1 no está disponible y los informa con FECAEARegInformativo cuando el servicio
+// vuelve. Al terminar cada quincena informa con FECAEASinMovimientoInformar los puntos de venta sin comprobantes.
+type ContingencyManager struct {
+	logger    *slog.Logger
+	store     *ContingencyStore
+	interval  time.Duration
+	cbteTipos []int32
+	trigger   chan struct{}
+	running   sync.Mutex
+
+	mu      sync.Mutex
+	tenants map[int64]*contingencyTenant
+}
+
+// NewContingencyManager crea el administrador de la contingencia de los CUIT de services. interval es cada
+// cuánto se verifican los CAEA y se informan los comprobantes pendientes. cbteTipos son los tipos de comprobante
+// cuya numeración se registra aunque todavía no se hayan emitido en contingencia.
+func NewContingencyManager(logger *slog.Logger, store *ContingencyStore, services []*Wsfe, interval time.Duration, cbteTipos []int32) *ContingencyManager {
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	}
+	m := &ContingencyManager{
+		logger:    logger,
+		store:     store,
+		interval:  interval,
+		cbteTipos: cbteTipos,
+		trigger:   make(chan struct{}, 1),
+		tenants:   make(map[int64]*contingencyTenant, len(services)),
+	}
+	for _, ws := range services {
+		m.tenants[ws.cuit] = &contingencyTenant{ws: ws}
+	}
+	return m
+}
+
+// Start ejecuta Run al iniciar, cada interval y con cada Trigger, hasta que ctx se cancele.
+func (m *ContingencyManager) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			m.Run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-m.trigger:
+			}
+		}
+	}()
+}
+
+// Trigger adelanta la próxima ejecución de Run.
+func (m *ContingencyManager) Trigger() {
+	select {
+	case m.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run obtiene los CAEA faltantes, informa los comprobantes pendientes y los puntos de venta sin movimiento de
+// cada CUIT.
+func (m *ContingencyManager) Run(ctx context.Context) {
+	m.running.Lock()
+	defer m.running.Unlock()
+
+	m.mu.Lock()
+	tenants := make([]*contingencyTenant, 0, len(m.tenants))
+	for _, t := range m.tenants {
+		tenants = append(tenants, t)
+	}
+	m.mu.Unlock()
+
+	for _, t := range tenants {
+		err := m.runTenant(ctx, t, time.Now())
+		if err != nil {
+			m.logger.Warn("Contingencia", "cuit", t.ws.cuit, "err", err.Error())
+		}
+
+		m.mu.Lock()
+		t.lastRun = time.Now()
+		t.lastError = ""
+		if err != nil {
+			t.lastError = err.Error()
+		}
+		m.mu.Unlock()
+	}
+}
+
+func (m *ContingencyManager) runTenant(ctx context.Context, t *contingencyTenant, now time.Time) error {
+	if err := m.refreshPtosVta(ctx, t); err != nil {
+		// Sin conexión con wsfev1 no tiene sentido continuar.
+		return err
+	}
+	var errs []error
+	if err := m.seedNumbering(ctx, t); err != nil {
+		errs = append(errs, err)
+	}
+	if err := m.ensureCAEAs(ctx, t, now); err != nil {
+		errs = append(errs, err)
+	}
+	if err := m.report(ctx, t, now); err != nil {
+		errs = append(errs, err)
+	}
+	if err := m.informWithoutMovement(ctx, t, now); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// refreshPtosVta consulta los puntos de venta habilitados para CAEA y la cantidad máxima de comprobantes por solicitud.
+func (m *ContingencyManager) refreshPtosVta(ctx context.Context, t *contingencyTenant) error {
+	result, err := t.ws.FEParamGetPtosVenta(ctx)
+	if err != nil && !isArcaError(err) {
+		return err
+	}
+
+	ptosVta := []int32{}
+	if result != nil && result.ResultGet != nil {
+		for _, p := range result.ResultGet.PtoVenta {
+			if p == nil || !strings.Contains(strings.ToUpper(p.EmisionTipo), "CAEA") || p.Bloqueado == "S" {
+				continue
+			}
+			if p.FchBaja != "" && p.FchBaja != "NULL" {
+				continue
+			}
+			ptosVta = append(ptosVta, p.Nro)
+		}
+	}
+
+	regXReq := 0
+	if total, err := t.ws.FECompTotXRequest(ctx); err == nil && total != nil {
+		regXReq = int(total.RegXReq)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t.ptosVta = ptosVta
+	if regXReq > 0 {
+		t.regXReq = regXReq
+	}
+	return nil
+}
+
+// seedNumbering registra, mientras wsfev1 está disponible, el último comprobante autorizado de cada punto de venta
+// CAEA en los tipos de comprobante configurados y en los ya numerados, para que Issue pueda continuar la
+// numeración sin conexión con ARCA.
+func (m *ContingencyManager) seedNumbering(ctx context.Context, t *contingencyTenant) error {
+	cbteTipos, err := m.store.CbteTipos(ctx, t.ws.cuit)
+	if err != nil {
+		return err
+	}
+	for _, cbteTipo := range m.cbteTipos {
+		if !slices.Contains(cbteTipos, cbteTipo) {
+			cbteTipos = append(cbteTipos, cbteTipo)
+		}
+	}
+
+	m.mu.Lock()
+	ptosVta := t.ptosVta
+	m.mu.Unlock()
+
+	var errs []error
+	for _, ptoVta := range ptosVta {
+		for _, cbteTipo := range cbteTipos {
+			last, err := t.ws.FEUltimoComprobanteEmitido(ctx, ptoVta, cbteTipo)
+			if err != nil {
+				if !isArcaError(err) {
+					return errors.Join(append(errs, err)...)
+				}
+				// ARCA rechaza los tipos de comprobante que el CUIT no puede emitir.
+				continue
+			}
+			if err := m.store.SaveLastAuthorized(ctx, t.ws.cuit, ptoVta, cbteTipo, int64(last.CbteNro)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ensureCAEAs obtiene el CAEA de la quincena actual y, dentro del plazo de solicitud, el de la siguiente.
+func (m *ContingencyManager) ensureCAEAs(ctx context.Context, t *contingencyTenant, now time.Time) error {
+	stored, err := m.store.CAEAs(ctx, t.ws.cuit)
+	if err != nil {
+		return err
+	}
+
+	current := fortnightOf(now)
+	var errs []error
+	for _, f := range []fortnight{current, current.next()} {
+		if slices.ContainsFunc(stored, func(c *CAEA) bool { return c.Periodo == f.periodo && c.Orden == f.orden }) {
+			continue
+		}
+		if now.Before(f.start().AddDate(0, 0, -caeaRequestDays)) {
+			continue
+		}
+		caea, err := m.obtainCAEA(ctx, t.ws, f)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CAEA %d/%d: %w", f.periodo, f.orden, err))
+			continue
+		}
+		if err := m.store.SaveCAEA(ctx, caea); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.logger.Info("CAEA obtenido", "cuit", caea.Cuit, "caea", caea.CAEA, "periodo", caea.Periodo, "orden", caea.Orden,
+			"vigDesde", caea.FchVigDesde, "vigHasta", caea.FchVigHasta, "topeInf", caea.FchTopeInf)
+	}
+	return errors.Join(errs...)
+}
+
+// obtainCAEA consulta el CAEA de la quincena y, si no fue otorgado, lo solicita.
+func (m *ContingencyManager) obtainCAEA(ctx context.Context, ws *Wsfe, f fortnight) (*CAEA, error) {
+	result, err := ws.FECAEAConsultar(ctx, f.periodo, f.orden)
+	if err != nil && !isArcaError(err) {
+		return nil, err
+	}
+	if err != nil || result == nil || result.ResultGet == nil || result.ResultGet.CAEA == "" {
+		result, err = ws.FECAEASolicitar(ctx, f.periodo, f.orden)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if result == nil || result.ResultGet == nil || result.ResultGet.CAEA == "" {
+		return nil, errors.New("ARCA no otorgó el CAEA")
+	}
+
+	get := result.ResultGet
+	return &CAEA{
+		Cuit:        ws.cuit,
+		CAEA:        get.CAEA,
+		Periodo:     f.periodo,
+		Orden:       f.orden,
+		FchVigDesde: get.FchVigDesde,
+		FchVigHasta: get.FchVigHasta,
+		FchTopeInf:  get.FchTopeInf,
+		FchProceso:  get.FchProceso,
+	}, nil
+}
+
+// Issue emite un comprobante con el CAEA vigente para su fecha: le asigna el número siguiente del punto de venta
+// y tipo, y lo guarda pendiente de informar. Si CbteFch está vacía se utiliza la fecha actual. El detalle se copia:
+// det no se modifica.
+func (m *ContingencyManager) Issue(ctx context.Context, cuit int64, ptoVta, cbteTipo int32, det *wsfe.FECAEADetRequest) (*ContingencyInvoice, error) {
+	m.mu.Lock()
+	t, exist := m.tenants[cuit]
+	var ptosVta []int32
+	if exist {
+		ptosVta = t.ptosVta
+	}
+	m.mu.Unlock()
+	if !exist {
+		return nil, fmt.Errorf("CUIT %d no configurado", cuit)
+	}
+	if det == nil || det.FEDetRequest == nil {
+		return nil, errors.New("falta el detalle del comprobante")
+	}
+	if ptosVta != nil && !slices.Contains(ptosVta, ptoVta) {
+		return nil, ErrContingencyPtoVta
+	}
+
+	fe := *det.FEDetRequest
+	copied := *det
+	copied.FEDetRequest = &fe
+	det = &copied
+
+	now := time.Now().In(argentina)
+	if det.CbteFch == "" {
+		det.CbteFch = now.Format("20060102")
+	}
+	if det.CbteFchHsGen == "" {
+		det.CbteFchHsGen = now.Format("20060102150405")
+	}
+
+	caeas, err := m.store.CAEAs(ctx, cuit)
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(caeas, func(c *CAEA) bool { return c.covers(det.CbteFch) })
+	if index < 0 {
+		return nil, ErrNoCAEA
+	}
+	det.CAEA = caeas[index].CAEA
+
+	// La numeración continúa la de ARCA, registrada por seedNumbering mientras wsfev1 está disponible, y la local.
+	// Si aún no se registró y no hay comprobantes locales, se consulta a ARCA.
+	lastAuthorized, seeded, err := m.store.LastAuthorized(ctx, cuit, ptoVta, cbteTipo)
+	if err != nil {
+		return nil, err
+	}
+	if !seeded {
+		numbered, err := m.store.HasNumbering(ctx, cuit, ptoVta, cbteTipo)
+		if err != nil {
+			return nil, err
+		}
+		if !numbered {
+			last, err := t.ws.FEUltimoComprobanteEmitido(ctx, ptoVta, cbteTipo)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrContingencyNumbering, err)
+			}
+			lastAuthorized = int64(last.CbteNro)
+			if err := m.store.SaveLastAuthorized(ctx, cuit, ptoVta, cbteTipo, lastAuthorized); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	invoice := &ContingencyInvoice{
+		Cuit:     cuit,
+		CAEA:     det.CAEA,
+		PtoVta:   ptoVta,
+		CbteTipo: cbteTipo,
+		CbteFch:  det.CbteFch,
+		Detalle:  det,
+	}
+	if err := m.store.Issue(ctx, invoice, lastAuthorized); err != nil {
+		return nil, err
+	}
+	m.logger.Info("Comprobante emitido en contingencia", "cuit", cuit, "caea", invoice.CAEA, "ptoVta", ptoVta, "cbteTipo", cbteTipo, "cbteNro", invoice.CbteNro)
+	return invoice, nil
+}
+
+// report informa los comprobantes pendientes agrupados por punto de venta y tipo, en lotes de FECompTotXRequest.
+func (m *ContingencyManager) report(ctx context.Context, t *contingencyTenant, now time.Time) error {
+	pending, err := m.store.Invoices(ctx, t.ws.cuit, ContingencyPending)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+
+	m.mu.Lock()
+	batchSize := t.regXReq
+	m.mu.Unlock()
+	if batchSize <= 0 {
+		return errors.New("FECompTotXRequest: se desconoce la cantidad máxima de comprobantes por solicitud")
+	}
+
+	for len(pending) > 0 {
+		// pending está ordenado por punto de venta, tipo y número.
+		end := 1
+		for end < len(pending) && pending[end].PtoVta == pending[0].PtoVta && pending[end].CbteTipo == pending[0].CbteTipo {
+			end++
+		}
+		group := m.reconcile(ctx, t.ws, pending[:end])
+		pending = pending[end:]
+
+		for len(group) > 0 {
+			n := min(batchSize, len(group))
+			if err := m.reportBatch(ctx, t.ws, group[:n]); err != nil {
+				return err
+			}
+			group = group[n:]
+		}
+	}
+	return m.warnDeadlines(ctx, t, now)
+}
+
+// reconcile marca como informados los comprobantes que ya se habían enviado y figuran en ARCA con su CAEA
+// (por ejemplo, si se perdió la respuesta), y devuelve los que falta informar.
+func (m *ContingencyManager) reconcile(ctx context.Context, ws *Wsfe, invoices []*ContingencyInvoice) []*ContingencyInvoice {
+	var pending []*ContingencyInvoice
+	for _, invoice := range invoices {
+		if invoice.Attempts > 0 {
+			result, err := ws.FECompConsultar(ctx, invoice.PtoVta, invoice.CbteTipo, invoice.CbteNro)
+			if err == nil && result != nil && result.ResultGet != nil && result.ResultGet.CodAutorizacion == invoice.CAEA {
+				invoice.Status = ContingencyReported
+				invoice.ReportedAt = time.Now()
+				invoice.LastError = ""
+				if err := m.store.UpdateInvoice(ctx, invoice); err != nil {
+					m.logger.Error("Contingencia", "cuit", invoice.Cuit, "id", invoice.ID, "err", err.Error())
+				}
+				continue
+			}
+		}
+		pending = append(pending, invoice)
+	}
+	return pending
+}
+
+// reportBatch informa un lote de comprobantes del mismo punto de venta y tipo. Devuelve error solo si no se
+// obtuvo respuesta de ARCA; los rechazos quedan registrados en cada comprobante.
+func (m *ContingencyManager) reportBatch(ctx context.Context, ws *Wsfe, invoices []*ContingencyInvoice) error {
+	cab := &wsfe.FECabRequest{
+		CantReg:  int32(len(invoices)),
+		PtoVta:   invoices[0].PtoVta,
+		CbteTipo: invoices[0].CbteTipo,
+	}
+	det := make([]*wsfe.FECAEADetRequest, len(invoices))
+	for i, invoice := range invoices {
+		det[i] = invoice.Detalle
+	}
+
+	result, err := ws.FECAEARegInformativo(ctx, cab, det)
+	if err != nil && !isArcaError(err) {
+		for _, invoice := range invoices {
+			invoice.Attempts++
+			invoice.LastError = err.Error()
+			if err := m.store.UpdateInvoice(ctx, invoice); err != nil {
+				m.logger.Error("Contingencia", "cuit", invoice.Cuit, "id", invoice.ID, "err", err.Error())
+			}
+		}
+		return err
+	}
+
+	results := make(map[int64]*wsfe.FEDetResponse)
+	if result != nil && result.FeDetResp != nil {
+		for _, d := range result.FeDetResp.FECAEADetResponse {
+			if d != nil && d.FEDetResponse != nil {
+				results[d.CbteDesde] = d.FEDetResponse
+			}
+		}
+	}
+
+	for _, invoice := range invoices {
+		invoice.Attempts++
+		d := results[invoice.CbteNro]
+		switch {
+		case d != nil && d.Resultado == ResultadoAprobado:
+			invoice.Status = ContingencyReported
+			invoice.ReportedAt = time.Now()
+			invoice.Observaciones = observations(d.Observaciones)
+			invoice.LastError = ""
+		case d != nil && d.Resultado == ResultadoRechazado:
+			invoice.Status = ContingencyRejected
+			invoice.Observaciones = observations(d.Observaciones)
+			invoice.LastError = ""
+			m.logger.Warn("Comprobante de contingencia rechazado", "cuit", invoice.Cuit, "ptoVta", invoice.PtoVta,
+				"cbteTipo", invoice.CbteTipo, "cbteNro", invoice.CbteNro, "observaciones", invoice.Observaciones)
+		case err != nil:
+			invoice.LastError = err.Error()
+		default:
+			invoice.LastError = "ARCA no informó el resultado del comprobante"
+		}
+		if err := m.store.UpdateInvoice(ctx, invoice); err != nil {
+			m.logger.Error("Contingencia", "cuit", invoice.Cuit, "id", invoice.ID, "err", err.Error())
+		}
+	}
+	return nil
+}
+
+// warnDeadlines advierte sobre los comprobantes pendientes cuyo CAEA vence su plazo de información en menos de dos días.
+func (m *ContingencyManager) warnDeadlines(ctx context.Context, t *contingencyTenant, now time.Time) error {
+	pending, err := m.store.Invoices(ctx, t.ws.cuit, ContingencyPending)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+	caeas, err := m.store.CAEAs(ctx, t.ws.cuit)
+	if err != nil {
+		return err
+	}
+
+	limit := now.In(argentina).AddDate(0, 0, 2).Format("20060102")
+	counts := make(map[string]int)
+	for _, invoice := range pending {
+		counts[invoice.CAEA]++
+	}
+	for _, caea := range caeas {
+		if counts[caea.CAEA] > 0 && caea.FchTopeInf <= limit {
+			m.logger.Warn("Comprobantes de contingencia pendientes de informar próximos al vencimiento del plazo",
+				"cuit", caea.Cuit, "caea", caea.CAEA, "topeInf", caea.FchTopeInf, "pendientes", counts[caea.CAEA])
+		}
+	}
+	return nil
+}
+
+// informWithoutMovement informa los puntos de venta CAEA sin comprobantes en cada quincena terminada cuyo plazo
+// de información no venció.
+func (m *ContingencyManager) informWithoutMovement(ctx context.Context, t *contingencyTenant, now time.Time) error {
+	caeas, err := m.store.CAEAs(ctx, t.ws.cuit)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	ptosVta := t.ptosVta
+	m.mu.Unlock()
+
+	today := now.In(argentina).Format("20060102")
+	var errs []error
+	for _, caea := range caeas {
+		if today <= caea.FchVigHasta || today > caea.FchTopeInf {
+			continue
+		}
+		for _, ptoVta := range ptosVta {
+			informed, err := m.store.WithoutMovementInformed(ctx, caea.Cuit, caea.CAEA, ptoVta)
+			if err != nil {
+				return err
+			}
+			moved, err := m.store.HasMovement(ctx, caea.Cuit, caea.CAEA, ptoVta)
+			if err != nil {
+				return err
+			}
+			if informed || moved {
+				continue
+			}
+
+			_, err = t.ws.FECAEASinMovimientoInformar(ctx, caea.CAEA, ptoVta)
+			if err != nil {
+				if !isArcaError(err) {
+					return err
+				}
+				// Puede haberse informado antes (por esta API o por otro medio).
+				if !m.informedInArca(ctx, t.ws, caea.CAEA, ptoVta) {
+					errs = append(errs, fmt.Errorf("CAEA %s punto de venta %d sin movimiento: %w", caea.CAEA, ptoVta, err))
+					continue
+				}
+			}
+			if err := m.store.SaveWithoutMovement(ctx, caea.Cuit, caea.CAEA, ptoVta, time.Now()); err != nil {
+				return err
+			}
+			m.logger.Info("Punto de venta informado sin movimiento", "cuit", caea.Cuit, "caea", caea.CAEA, "ptoVta", ptoVta)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *ContingencyManager) informedInArca(ctx context.Context, ws *Wsfe, caea string, ptoVta int32) bool {
+	result, err := ws.FECAEASinMovimientoConsultar(ctx, caea, ptoVta)
+	if err != nil || result == nil || result.ResultGet == nil {
+		return false
+	}
+	return slices.ContainsFunc(result.ResultGet.FECAEASinMov, func(s *wsfe.FECAEASinMov) bool {
+		return s != nil && s.PtoVta == ptoVta
+	})
+}
+
+// Status devuelve el estado de la contingencia del CUIT.
+func (m *ContingencyManager) Status(ctx context.Context, cuit int64) (*ContingencyStatus, error) {
+	status := &ContingencyStatus{Cuit: cuit, CAEAs: []*CAEA{}}
+	m.mu.Lock()
+	if t, exist := m.tenants[cuit]; exist {
+		status.PtosVta = t.ptosVta
+		status.LastRun = t.lastRun
+		status.LastError = t.lastError
+	}
+	m.mu.Unlock()
+
+	caeas, err := m.store.CAEAs(ctx, cuit)
+	if err != nil {
+		return nil, err
+	}
+	if caeas != nil {
+		status.CAEAs = caeas
+	}
+	counts, err := m.store.CountInvoices(ctx, cuit)
+	if err != nil {
+		return nil, err
+	}
+	status.Pending = counts[ContingencyPending]
+	status.Rejected = counts[ContingencyRejected]
+	return status, nil
+}
+
+// Invoices devuelve los comprobantes de contingencia del CUIT con el estado indicado (todos si status está vacío).
+func (m *ContingencyManager) Invoices(ctx context.Context, cuit int64, status string) ([]*ContingencyInvoice, error) {
+	return m.store.Invoices(ctx, cuit, status)
+}
+
+// Resubmit reemplaza el detalle de un comprobante rechazado por ARCA por el corregido y lo vuelve a pendiente,
+// para informarlo en la próxima verificación, que se adelanta. Se conservan el número, la fecha y el CAEA del
+// comprobante. det no se modifica.
+func (m *ContingencyManager) Resubmit(ctx context.Context, cuit, id int64, det *wsfe.FECAEADetRequest) (*ContingencyInvoice, error) {
+	if det == nil || det.FEDetRequest == nil {
+		return nil, errors.New("falta el detalle del comprobante")
+	}
+	fe := *det.FEDetRequest
+	copied := *det
+	copied.FEDetRequest = &fe
+
+	if err := m.store.ResubmitInvoice(ctx, cuit, id, &copied); err != nil {
+		return nil, err
+	}
+	invoice, err := m.store.Invoice(ctx, cuit, id)
+	if err != nil {
+		return nil, err
+	}
+	m.logger.Info("Comprobante de contingencia corregido", "cuit", cuit, "ptoVta", invoice.PtoVta, "cbteTipo", invoice.CbteTipo, "cbteNro", invoice.CbteNro)
+	m.Trigger()
+	return invoice, nil
+}
+
+func isArcaError(err error) bool {
+	var arcaErr *ArcaError
+	return errors.As(err, &arcaErr)
+}
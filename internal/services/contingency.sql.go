@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sehogas/goarca/ws/wsfe"
+	_ "modernc.org/sqlite"
+)
+
+// ContingencyStore almacena en una base SQLite embebida los CAEA otorgados, el último comprobante autorizado en
+// ARCA de cada punto de venta y tipo, los comprobantes emitidos en contingencia y los puntos de venta informados
+// sin movimiento.
+type ContingencyStore struct {
+	db *sql.DB
+}
+
+func NewContingencyStore(fileName string) (*ContingencyStore, error) {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0750); err != nil {
+		return nil, fmt.Errorf("NewContingencyStore: %s", err)
+	}
+	db, err := sql.Open("sqlite", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("NewContingencyStore: %s", err)
+	}
+	// SQLite admite un único escritor; se serializa el acceso desde este proceso, lo que además hace atómica
+	// la numeración de los comprobantes.
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range []string{
+		`CREATE TABLE IF NOT EXISTS caeas (
+			cuit           INTEGER NOT NULL,
+			periodo        INTEGER NOT NULL,
+			orden          INTEGER NOT NULL,
+			caea           TEXT    NOT NULL,
+			fch_vig_desde  TEXT    NOT NULL,
+			fch_vig_hasta  TEXT    NOT NULL,
+			fch_tope_inf   TEXT    NOT NULL,
+			fch_proceso    TEXT    NOT NULL DEFAULT '',
+			PRIMARY KEY (cuit, periodo, orden)
+		)`,
+		`CREATE TABLE IF NOT EXISTS contingency_invoices (
+			id           INTEGER PRIMARY KEY AUTOINCREMENT,
+			cuit         INTEGER NOT NULL,
+			caea         TEXT    NOT NULL,
+			pto_vta      INTEGER NOT NULL,
+			cbte_tipo    INTEGER NOT NULL,
+			cbte_nro     INTEGER NOT NULL,
+			cbte_fch     TEXT    NOT NULL,
+			detail       TEXT    NOT NULL,
+			status       TEXT    NOT NULL,
+			observations TEXT    NOT NULL DEFAULT '',
+			attempts     INTEGER NOT NULL DEFAULT 0,
+			last_error   TEXT    NOT NULL DEFAULT '',
+			created      TEXT    NOT NULL,
+			reported     TEXT    NOT NULL DEFAULT '',
+			UNIQUE (cuit, pto_vta, cbte_tipo, cbte_nro)
+		)`,
+		`CREATE INDEX IF NOT EXISTS contingency_invoices_status ON contingency_invoices (cuit, status)`,
+		`CREATE TABLE IF NOT EXISTS contingency_numbering (
+			cuit      INTEGER NOT NULL,
+			pto_vta   INTEGER NOT NULL,
+			cbte_tipo INTEGER NOT NULL,
+			cbte_nro  INTEGER NOT NULL,
+			updated   TEXT    NOT NULL,
+			PRIMARY KEY (cuit, pto_vta, cbte_tipo)
+		)`,
+		`CREATE TABLE IF NOT EXISTS caea_without_movement (
+			cuit     INTEGER NOT NULL,
+			caea     TEXT    NOT NULL,
+			pto_vta  INTEGER NOT NULL,
+			informed TEXT    NOT NULL,
+			PRIMARY KEY (cuit, caea, pto_vta)
+		)`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("NewContingencyStore: %s", err)
+		}
+	}
+	return &ContingencyStore{db: db}, nil
+}
+
+func (s *ContingencyStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *ContingencyStore) SaveCAEA(ctx context.Context, caea *CAEA) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO caeas (cuit, periodo, orden, caea, fch_vig_desde, fch_vig_hasta, fch_tope_inf, fch_proceso) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (cuit, periodo, orden) DO UPDATE SET caea = excluded.caea, fch_vig_desde = excluded.fch_vig_desde,
+			fch_vig_hasta = excluded.fch_vig_hasta, fch_tope_inf = excluded.fch_tope_inf, fch_proceso = excluded.fch_proceso`,
+		caea.Cuit, caea.Periodo, caea.Orden, caea.CAEA, caea.FchVigDesde, caea.FchVigHasta, caea.FchTopeInf, caea.FchProceso)
+	return err
+}
+
+// CAEAs devuelve los CAEA del CUIT, del más antiguo al más reciente.
+func (s *ContingencyStore) CAEAs(ctx context.Context, cuit int64) ([]*CAEA, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT periodo, orden, caea, fch_vig_desde, fch_vig_hasta, fch_tope_inf, fch_proceso FROM caeas WHERE cuit = ? ORDER BY periodo, orden`, cuit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var caeas []*CAEA
+	for rows.Next() {
+		caea := &CAEA{Cuit: cuit}
+		if err := rows.Scan(&caea.Periodo, &caea.Orden, &caea.CAEA, &caea.FchVigDesde, &caea.FchVigHasta, &caea.FchTopeInf, &caea.FchProceso); err != nil {
+			return nil, err
+		}
+		caeas = append(caeas, caea)
+	}
+	return caeas, rows.Err()
+}
+
+// SaveLastAuthorized registra el último comprobante autorizado en ARCA del punto de venta y tipo, desde el que
+// continúa la numeración de los comprobantes emitidos en contingencia.
+func (s *ContingencyStore) SaveLastAuthorized(ctx context.Context, cuit int64, ptoVta, cbteTipo int32, cbteNro int64) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO contingency_numbering (cuit, pto_vta, cbte_tipo, cbte_nro, updated) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (cuit, pto_vta, cbte_tipo) DO UPDATE SET cbte_nro = excluded.cbte_nro, updated = excluded.updated`,
+		cuit, ptoVta, cbteTipo, cbteNro, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// LastAuthorized devuelve el último comprobante autorizado en ARCA registrado con SaveLastAuthorized e indica si
+// se registró.
+func (s *ContingencyStore) LastAuthorized(ctx context.Context, cuit int64, ptoVta, cbteTipo int32) (int64, bool, error) {
+	var cbteNro int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT cbte_nro FROM contingency_numbering WHERE cuit = ? AND pto_vta = ? AND cbte_tipo = ?`,
+		cuit, ptoVta, cbteTipo).Scan(&cbteNro)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return cbteNro, err == nil, err
+}
+
+// CbteTipos devuelve los tipos de comprobante del CUIT con numeración registrada o comprobantes emitidos.
+func (s *ContingencyStore) CbteTipos(ctx context.Context, cuit int64) ([]int32, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT cbte_tipo FROM contingency_numbering WHERE cuit = ?
+		UNION SELECT cbte_tipo FROM contingency_invoices WHERE cuit = ? ORDER BY cbte_tipo`,
+		cuit, cuit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cbteTipos []int32
+	for rows.Next() {
+		var cbteTipo int32
+		if err := rows.Scan(&cbteTipo); err != nil {
+			return nil, err
+		}
+		cbteTipos = append(cbteTipos, cbteTipo)
+	}
+	return cbteTipos, rows.Err()
+}
+
+// HasNumbering indica si ya se emitieron comprobantes del punto de venta y tipo, es decir, si la numeración es local.
+func (s *ContingencyStore) HasNumbering(ctx context.Context, cuit int64, ptoVta, cbteTipo int32) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM contingency_invoices WHERE cuit = ? AND pto_vta = ? AND cbte_tipo = ?`,
+		cuit, ptoVta, cbteTipo).Scan(&count)
+	return count > 0, err
+}
+
+// Issue numera y guarda el comprobante como pendiente. El número es el siguiente al mayor entre el último
+// emitido en contingencia y lastAuthorized (el último comprobante autorizado en ARCA).
+func (s *ContingencyStore) Issue(ctx context.Context, invoice *ContingencyInvoice, lastAuthorized int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var last sql.NullInt64
+	err = tx.QueryRowContext(ctx,
+		`SELECT MAX(cbte_nro) FROM contingency_invoices WHERE cuit = ? AND pto_vta = ? AND cbte_tipo = ?`,
+		invoice.Cuit, invoice.PtoVta, invoice.CbteTipo).Scan(&last)
+	if err != nil {
+		return err
+	}
+	invoice.CbteNro = max(last.Int64, lastAuthorized) + 1
+	invoice.Detalle.CbteDesde = invoice.CbteNro
+	invoice.Detalle.CbteHasta = invoice.CbteNro
+	invoice.Status = ContingencyPending
+	invoice.CreatedAt = time.Now().UTC()
+
+	detail, err := json.Marshal(invoice.Detalle)
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO contingency_invoices (cuit, caea, pto_vta, cbte_tipo, cbte_nro, cbte_fch, detail, status, created) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		invoice.Cuit, invoice.CAEA, invoice.PtoVta, invoice.CbteTipo, invoice.CbteNro, invoice.CbteFch, string(detail), invoice.Status, invoice.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	if invoice.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Invoices devuelve los comprobantes del CUIT con el estado indicado (todos si status está vacío), ordenados por
+// punto de venta, tipo y número.
+func (s *ContingencyStore) Invoices(ctx context.Context, cuit int64, status string) ([]*ContingencyInvoice, error) {
+	return s.queryInvoices(ctx, cuit, `(? = '' OR status = ?) ORDER BY pto_vta, cbte_tipo, cbte_nro`, status, status)
+}
+
+// Invoice devuelve el comprobante id del CUIT, o ErrContingencyInvoiceNotFound.
+func (s *ContingencyStore) Invoice(ctx context.Context, cuit, id int64) (*ContingencyInvoice, error) {
+	invoices, err := s.queryInvoices(ctx, cuit, `id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, ErrContingencyInvoiceNotFound
+	}
+	return invoices[0], nil
+}
+
+func (s *ContingencyStore) queryInvoices(ctx context.Context, cuit int64, where string, args ...any) ([]*ContingencyInvoice, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, caea, pto_vta, cbte_tipo, cbte_nro, cbte_fch, detail, status, observations, attempts, last_error, created, reported
+		FROM contingency_invoices WHERE cuit = ? AND `+where,
+		append([]any{cuit}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoices []*ContingencyInvoice
+	for rows.Next() {
+		invoice := &ContingencyInvoice{Cuit: cuit}
+		var detail, observations, created, reported string
+		if err := rows.Scan(&invoice.ID, &invoice.CAEA, &invoice.PtoVta, &invoice.CbteTipo, &invoice.CbteNro, &invoice.CbteFch,
+			&detail, &invoice.Status, &observations, &invoice.Attempts, &invoice.LastError, &created, &reported); err != nil {
+			return nil, err
+		}
+		invoice.Detalle = &wsfe.FECAEADetRequest{}
+		if err := json.Unmarshal([]byte(detail), invoice.Detalle); err != nil {
+			return nil, fmt.Errorf("ContingencyStore: comprobante %d inválido: %s", invoice.ID, err)
+		}
+		if observations != "" {
+			json.Unmarshal([]byte(observations), &invoice.Observaciones)
+		}
+		invoice.CreatedAt, _ = time.Parse(time.RFC3339, created)
+		invoice.ReportedAt, _ = time.Parse(time.RFC3339, reported)
+		invoices = append(invoices, invoice)
+	}
+	return invoices, rows.Err()
+}
+
+// CountInvoices devuelve la cantidad de comprobantes del CUIT en cada estado.
+func (s *ContingencyStore) CountInvoices(ctx context.Context, cuit int64) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT status, COUNT(*) FROM contingency_invoices WHERE cuit = ? GROUP BY status`, cuit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
+
+// ResubmitInvoice reemplaza el detalle de un comprobante rechazado por el corregido y lo vuelve a pendiente. El
+// comprobante conserva su número, fecha y CAEA, que se copian en det.
+func (s *ContingencyStore) ResubmitInvoice(ctx context.Context, cuit, id int64, det *wsfe.FECAEADetRequest) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status, caea, cbteFch string
+	var cbteNro int64
+	err = tx.QueryRowContext(ctx,
+		`SELECT status, caea, cbte_nro, cbte_fch FROM contingency_invoices WHERE cuit = ? AND id = ?`,
+		cuit, id).Scan(&status, &caea, &cbteNro, &cbteFch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrContingencyInvoiceNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != ContingencyRejected {
+		return ErrContingencyNotRejected
+	}
+
+	det.CAEA = caea
+	det.CbteDesde = cbteNro
+	det.CbteHasta = cbteNro
+	det.CbteFch = cbteFch
+	detail, err := json.Marshal(det)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE contingency_invoices SET detail = ?, status = ?, observations = '', last_error = '' WHERE id = ?`,
+		string(detail), ContingencyPending, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateInvoice guarda el estado, las observaciones y los intentos de informar el comprobante.
+func (s *ContingencyStore) UpdateInvoice(ctx context.Context, invoice *ContingencyInvoice) error {
+	var observations []byte
+	if len(invoice.Observaciones) > 0 {
+		observations, _ = json.Marshal(invoice.Observaciones)
+	}
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE contingency_invoices SET status = ?, observations = ?, attempts = ?, last_error = ?, reported = ? WHERE id = ?`,
+		invoice.Status, string(observations), invoice.Attempts, invoice.LastError, formatOptionalTime(invoice.ReportedAt), invoice.ID)
+	return err
+}
+
+// HasMovement indica si se emitieron comprobantes con el CAEA en el punto de venta.
+func (s *ContingencyStore) HasMovement(ctx context.Context, cuit int64, caea string, ptoVta int32) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM contingency_invoices WHERE cuit = ? AND caea = ? AND pto_vta = ?`,
+		cuit, caea, ptoVta).Scan(&count)
+	return count > 0, err
+}
+
+// WithoutMovementInformed indica si el punto de venta ya se informó sin movimiento para el CAEA.
+func (s *ContingencyStore) WithoutMovementInformed(ctx context.Context, cuit int64, caea string, ptoVta int32) (bool, error) {
+	var informed string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT informed FROM caea_without_movement WHERE cuit = ? AND caea = ? AND pto_vta = ?`,
+		cuit, caea, ptoVta).Scan(&informed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *ContingencyStore) SaveWithoutMovement(ctx context.Context, cuit int64, caea string, ptoVta int32, informed time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO caea_without_movement (cuit, caea, pto_vta, informed) VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING`,
+		cuit, caea, ptoVta, informed.UTC().Format(time.RFC3339))
+	return err
+}
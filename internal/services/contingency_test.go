@@ -0,0 +1,263 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sehogas/goarca/ws/wsfe"
+)
+
+var (
+	cbteTipoPattern  = regexp.MustCompile(`CbteTipo>(\d+)<`)
+	caeaDetPattern   = regexp.MustCompile(`(?s)<(?:\w+:)?FECAEADetRequest>.*?</(?:\w+:)?FECAEADetRequest>`)
+	cbteDesdePattern = regexp.MustCompile(`CbteDesde>(\d+)<`)
+)
+
+// rejectedDocNro es el documento con el que el FECAEARegInformativo de respondContingency rechaza el comprobante.
+const rejectedDocNro = "11111111"
+
+// respondContingency registra en fake las respuestas de las consultas de wsfev1 que utiliza ContingencyManager,
+// con el último comprobante autorizado de cada tipo en lastCbteNro, y cuenta las consultas del último autorizado.
+func respondContingency(fake *fakeArca, lastCbteNro map[string]int32) *atomic.Int32 {
+	var lastCalls atomic.Int32
+	fake.respond("FEParamGetPtosVenta", func([]byte) string {
+		return `<ResultGet>` +
+			`<PtoVenta><Nro>2</Nro><EmisionTipo>CAE - Ws</EmisionTipo><Bloqueado>N</Bloqueado><FchBaja>NULL</FchBaja></PtoVenta>` +
+			`<PtoVenta><Nro>5</Nro><EmisionTipo>CAEA - Ws</EmisionTipo><Bloqueado>N</Bloqueado><FchBaja>NULL</FchBaja></PtoVenta>` +
+			`</ResultGet>`
+	})
+	fake.respond("FECompTotXRequest", func([]byte) string {
+		return `<RegXReq>250</RegXReq>`
+	})
+	fake.respond("FECompUltimoAutorizado", func(body []byte) string {
+		lastCalls.Add(1)
+		var cbteNro int32
+		exist := false
+		cbteTipo := cbteTipoPattern.FindSubmatch(body)
+		if cbteTipo != nil {
+			cbteNro, exist = lastCbteNro[string(cbteTipo[1])]
+		}
+		if !exist {
+			return `<Errors><Err><Code>10015</Code><Msg>El tipo de comprobante no es válido para el emisor</Msg></Err></Errors>`
+		}
+		return fmt.Sprintf(`<PtoVta>5</PtoVta><CbteTipo>%s</CbteTipo><CbteNro>%d</CbteNro>`, cbteTipo[1], cbteNro)
+	})
+	fake.respond("FECompConsultar", func([]byte) string {
+		return `<Errors><Err><Code>602</Code><Msg>No existen datos en nuestros registros para los parametros ingresados</Msg></Err></Errors>`
+	})
+	fake.respond("FECAEARegInformativo", func(body []byte) string {
+		var details strings.Builder
+		for _, det := range caeaDetPattern.FindAll(body, -1) {
+			cbteNro := cbteDesdePattern.FindSubmatch(det)[1]
+			if bytes.Contains(det, []byte(">"+rejectedDocNro+"<")) {
+				fmt.Fprintf(&details, `<FECAEADetResponse><CbteDesde>%s</CbteDesde><CbteHasta>%s</CbteHasta><Resultado>R</Resultado>`+
+					`<Observaciones><Obs><Code>10015</Code><Msg>El documento del receptor es inválido</Msg></Obs></Observaciones></FECAEADetResponse>`, cbteNro, cbteNro)
+			} else {
+				fmt.Fprintf(&details, `<FECAEADetResponse><CbteDesde>%s</CbteDesde><CbteHasta>%s</CbteHasta><Resultado>A</Resultado></FECAEADetResponse>`, cbteNro, cbteNro)
+			}
+		}
+		return `<FeCabResp><Resultado>A</Resultado></FeCabResp><FeDetResp>` + details.String() + `</FeDetResp>`
+	})
+	return &lastCalls
+}
+
+// contingencyFixture es un ContingencyManager de un CUIT contra fakeArca, con el punto de venta CAEA 5 y el
+// CAEA de la quincena actual.
+type contingencyFixture struct {
+	manager   *ContingencyManager
+	store     *ContingencyStore
+	fake      *fakeArca
+	lastCalls *atomic.Int32
+	tenant    *contingencyTenant
+	cuit      int64
+}
+
+func newContingencyFixture(t *testing.T, cbteTipos []int32) *contingencyFixture {
+	t.Helper()
+	fake := newFakeArca(t)
+	lastCalls := respondContingency(fake, map[string]int32{"1": 120, "6": 7, "11": 40})
+	SetTicketStore(&FileTicketStore{dir: t.TempDir()})
+	t.Cleanup(func() { SetTicketStore(&FileTicketStore{dir: "data"}) })
+	retryPolicyMu.RLock()
+	previous := retryPolicy
+	retryPolicyMu.RUnlock()
+	SetRetryPolicy(RetryPolicy{})
+	t.Cleanup(func() { SetRetryPolicy(previous) })
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tenant := testTenant(t, 20111111112)
+	ws, err := NewWsfe(logger, TESTING, tenant, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewContingencyStore(filepath.Join(t.TempDir(), "contingency.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	manager := NewContingencyManager(logger, store, []*Wsfe{ws}, time.Hour, cbteTipos)
+	f := &contingencyFixture{manager: manager, store: store, fake: fake, lastCalls: lastCalls, tenant: manager.tenants[tenant.Cuit], cuit: tenant.Cuit}
+
+	ctx := context.Background()
+	if err := manager.refreshPtosVta(ctx, f.tenant); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().In(argentina)
+	if err := store.SaveCAEA(ctx, &CAEA{
+		Cuit:        tenant.Cuit,
+		CAEA:        "36123456789012",
+		Periodo:     int32(now.Year()*100 + int(now.Month())),
+		Orden:       1,
+		FchVigDesde: now.AddDate(0, 0, -1).Format("20060102"),
+		FchVigHasta: now.AddDate(0, 0, 1).Format("20060102"),
+		FchTopeInf:  now.AddDate(0, 0, 9).Format("20060102"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func testCAEADet(docNro int64) *wsfe.FECAEADetRequest {
+	return &wsfe.FECAEADetRequest{FEDetRequest: &wsfe.FEDetRequest{Concepto: 1, DocTipo: 80, DocNro: docNro, ImpTotal: 121, ImpNeto: 100, ImpIVA: 21, MonId: "PES", MonCotiz: 1}}
+}
+
+// TestContingencyOfflineNumbering verifica que la numeración de los tipos configurados y de los ya numerados se
+// registra mientras wsfev1 está disponible y permite emitir comprobantes en contingencia sin conexión, y que Issue
+// no modifica el detalle recibido.
+func TestContingencyOfflineNumbering(t *testing.T) {
+	f := newContingencyFixture(t, []int32{1, 99})
+	ctx := context.Background()
+
+	// El tipo 6 ya se numeró con una consulta anterior; el 11 no se configuró ni se utilizó.
+	if err := f.store.SaveLastAuthorized(ctx, f.cuit, 5, 6, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.manager.seedNumbering(ctx, f.tenant); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.lastCalls.Load(); n != 3 {
+		t.Errorf("consultas del último autorizado = %d, se esperaban 3 (tipos 1, 6 y 99 del punto de venta 5)", n)
+	}
+	for _, c := range []struct {
+		cbteTipo int32
+		cbteNro  int64
+		seeded   bool
+	}{{1, 120, true}, {6, 7, true}, {11, 0, false}, {99, 0, false}} {
+		cbteNro, seeded, err := f.store.LastAuthorized(ctx, f.cuit, 5, c.cbteTipo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cbteNro != c.cbteNro || seeded != c.seeded {
+			t.Errorf("último autorizado del tipo %d = %d (%v), se esperaba %d (%v)", c.cbteTipo, cbteNro, seeded, c.cbteNro, c.seeded)
+		}
+	}
+	if _, seeded, _ := f.store.LastAuthorized(ctx, f.cuit, 2, 1); seeded {
+		t.Error("se registró la numeración de un punto de venta CAE")
+	}
+
+	f.fake.down.Store(true)
+	det := testCAEADet(20222222223)
+	for _, want := range []int64{121, 122} {
+		invoice, err := f.manager.Issue(ctx, f.cuit, 5, 1, det)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if invoice.CbteNro != want || invoice.Detalle.CbteDesde != want || invoice.Detalle.CbteHasta != want || invoice.Detalle.CAEA != "36123456789012" {
+			t.Errorf("comprobante emitido = %d (%d-%d) CAEA %q, se esperaba %d", invoice.CbteNro, invoice.Detalle.CbteDesde, invoice.Detalle.CbteHasta, invoice.Detalle.CAEA, want)
+		}
+	}
+	if det.CAEA != "" || det.CbteFch != "" || det.CbteFchHsGen != "" || det.CbteDesde != 0 || det.CbteHasta != 0 {
+		t.Errorf("Issue modificó el detalle recibido: %+v", det.FEDetRequest)
+	}
+	if calls := f.fake.downCalls.Load(); calls != 0 {
+		t.Errorf("se enviaron %d solicitudes a wsfev1 sin conexión", calls)
+	}
+
+	if _, err := f.manager.Issue(ctx, f.cuit, 5, 11, det); !errors.Is(err, ErrContingencyNumbering) {
+		t.Errorf("Issue sin numeración registrada ni conexión = %v, se esperaba ErrContingencyNumbering", err)
+	}
+}
+
+// TestContingencyResubmit verifica que un comprobante rechazado al informarlo se corrige con Resubmit, conserva
+// su número, fecha y CAEA y se informa nuevamente, y que Status cuenta los comprobantes de cada estado.
+func TestContingencyResubmit(t *testing.T) {
+	f := newContingencyFixture(t, []int32{1})
+	ctx := context.Background()
+	if err := f.manager.seedNumbering(ctx, f.tenant); err != nil {
+		t.Fatal(err)
+	}
+
+	approved, err := f.manager.Issue(ctx, f.cuit, 5, 1, testCAEADet(20222222223))
+	if err != nil {
+		t.Fatal(err)
+	}
+	docNro, _ := strconv.ParseInt(rejectedDocNro, 10, 64)
+	rejected, err := f.manager.Issue(ctx, f.cuit, 5, 1, testCAEADet(docNro))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.manager.report(ctx, f.tenant, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	status, err := f.manager.Status(ctx, f.cuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Pending != 0 || status.Rejected != 1 {
+		t.Errorf("Status() = %d pendientes y %d rechazados, se esperaba 0 y 1", status.Pending, status.Rejected)
+	}
+
+	corrected := testCAEADet(20333333334)
+	for _, c := range []struct {
+		name string
+		cuit int64
+		id   int64
+		want error
+	}{
+		{"informado", f.cuit, approved.ID, ErrContingencyNotRejected},
+		{"inexistente", f.cuit, rejected.ID + 100, ErrContingencyInvoiceNotFound},
+		{"de otro CUIT", 20222222223, rejected.ID, ErrContingencyInvoiceNotFound},
+	} {
+		if _, err := f.manager.Resubmit(ctx, c.cuit, c.id, corrected); !errors.Is(err, c.want) {
+			t.Errorf("Resubmit() %s = %v, se esperaba %v", c.name, err, c.want)
+		}
+	}
+
+	invoice, err := f.manager.Resubmit(ctx, f.cuit, rejected.ID, corrected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invoice.Status != ContingencyPending || len(invoice.Observaciones) != 0 || invoice.CbteNro != rejected.CbteNro ||
+		invoice.Detalle.CbteDesde != rejected.CbteNro || invoice.Detalle.CbteFch != rejected.CbteFch ||
+		invoice.Detalle.CAEA != rejected.CAEA || invoice.Detalle.DocNro != 20333333334 {
+		t.Errorf("comprobante corregido = %+v, detalle %+v", invoice, invoice.Detalle.FEDetRequest)
+	}
+	if corrected.CAEA != "" || corrected.CbteDesde != 0 || corrected.CbteFch != "" {
+		t.Errorf("Resubmit modificó el detalle recibido: %+v", corrected.FEDetRequest)
+	}
+
+	if err := f.manager.report(ctx, f.tenant, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	reported, err := f.store.Invoice(ctx, f.cuit, rejected.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reported.Status != ContingencyReported {
+		t.Errorf("estado después de informar la corrección = %s, se esperaba %s", reported.Status, ContingencyReported)
+	}
+	if status, err := f.manager.Status(ctx, f.cuit); err != nil || status.Pending != 0 || status.Rejected != 0 {
+		t.Errorf("Status() = %+v, %v, se esperaba sin pendientes ni rechazados", status, err)
+	}
+}
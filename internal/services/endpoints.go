@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"sync"
+)
+
+// Servicios de ARCA cuya URL puede configurarse con SetServiceURLs.
+const (
+	EndpointWSAA       = "wsaa"
+	EndpointWSFE       = "wsfe"
+	EndpointWSCOEM     = "wscoem"
+	EndpointWSCOEMCons = "wscoemcons"
+	EndpointWGESTABREF = "wgestabref"
+)
+
+var defaultServiceURLs = map[Environment]map[string]string{
+	TESTING: {
+		EndpointWSAA:       URLWSAATesting,
+		EndpointWSFE:       URLWSFETesting,
+		EndpointWSCOEM:     URLWSCOEMTesting,
+		EndpointWSCOEMCons: URLWSCOEMConsTesting,
+		EndpointWGESTABREF: URLWGESTABREFTesting,
+	},
+	PRODUCTION: {
+		EndpointWSAA:       URLWSAAProduction,
+		EndpointWSFE:       URLWSFEProduction,
+		EndpointWSCOEM:     URLWSCOEMProduction,
+		EndpointWSCOEMCons: URLWSCOEMConsProduction,
+		EndpointWGESTABREF: URLWGESTABREFProduction,
+	},
+}
+
+var (
+	serviceURLsMu sync.RWMutex
+	serviceURLs   = make(map[Environment]map[string]string)
+)
+
+// SetServiceURLs reemplaza las URL de los servicios de ARCA del ambiente. Las claves son los nombres
+// Endpoint*; los servicios sin URL configurada utilizan las constantes URL* del ambiente.
+func SetServiceURLs(environment Environment, urls map[string]string) error {
+	overrides := make(map[string]string, len(urls))
+	for service, rawURL := range urls {
+		if _, exist := defaultServiceURLs[environment][service]; !exist {
+			return fmt.Errorf("SetServiceURLs: servicio desconocido: %s", service)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("SetServiceURLs: URL inválida para %s: %s", service, rawURL)
+		}
+		overrides[service] = rawURL
+	}
+
+	serviceURLsMu.Lock()
+	defer serviceURLsMu.Unlock()
+	serviceURLs[environment] = overrides
+	return nil
+}
+
+// ServiceURL devuelve la URL efectiva del servicio de ARCA en el ambiente.
+func ServiceURL(environment Environment, service string) string {
+	serviceURLsMu.RLock()
+	defer serviceURLsMu.RUnlock()
+	if rawURL, exist := serviceURLs[environment][service]; exist {
+		return rawURL
+	}
+	return defaultServiceURLs[environment][service]
+}
+
+// ServiceURLs devuelve las URL efectivas de todos los servicios de ARCA en el ambiente.
+func ServiceURLs(environment Environment) map[string]string {
+	serviceURLsMu.RLock()
+	defer serviceURLsMu.RUnlock()
+	result := maps.Clone(defaultServiceURLs[environment])
+	maps.Copy(result, serviceURLs[environment])
+	return result
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hooklift/gowsdl/soap"
+	"github.com/sehogas/goarca/ws/wgestabref"
+	"github.com/sehogas/goarca/ws/wscoem"
+	"github.com/sehogas/goarca/ws/wscoemcons"
+	"github.com/sehogas/goarca/ws/wsfe"
+)
+
+// TransportError indica que no se obtuvo una respuesta SOAP de ARCA: error de conexión o
+// respuesta HTTP de error sin falla SOAP.
+type TransportError struct {
+	ServiceName string
+	Operation   string
+	StatusCode  int
+	Err         error
+}
+
+func (e *TransportError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s: %s respondió HTTP %d", e.Operation, e.ServiceName, e.StatusCode)
+	}
+	return fmt.Sprintf("%s: error de comunicación con %s: %s", e.Operation, e.ServiceName, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// SoapFaultError es una falla SOAP devuelta por un servicio de ARCA, con su código sin prefijo de espacio de nombres.
+type SoapFaultError struct {
+	ServiceName string
+	Operation   string
+	Code        string
+	Message     string
+}
+
+func (e *SoapFaultError) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", e.Operation, e.Message, e.Code)
+}
+
+// ArcaMessage es un error de negocio informado por ARCA.
+type ArcaMessage struct {
+	Code    string
+	Message string
+}
+
+// ArcaError indica que ARCA procesó la solicitud pero la rechazó, informando los errores dentro de
+// la respuesta (Errors en wsfe, ListaErrores en wscoem, Errores en wscoemcons y CodError en wgestabref).
+// En modo estricto también se informan así los comprobantes rechazados o aprobados parcialmente, con
+// las advertencias de la respuesta en Warnings y la respuesta completa en Result: en una aprobación
+// parcial ARCA ya otorgó el CAE de los comprobantes aprobados.
+type ArcaError struct {
+	ServiceName string
+	Operation   string
+	Errors      []ArcaMessage
+	Warnings    []ArcaMessage
+	Result      any
+}
+
+func (e *ArcaError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, m := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s - %s", m.Code, m.Message))
+	}
+	return fmt.Sprintf("%s: %s rechazó la solicitud: %s", e.Operation, e.ServiceName, strings.Join(messages, "; "))
+}
+
+// classifyError convierte el error del cliente SOAP en un *SoapFaultError o un *TransportError. Los errores ya
+// clasificados, como los de WSAA al obtener el ticket de acceso, se devuelven sin cambios.
+func classifyError(info *operationInfo, err error) error {
+	var httpErr *soap.HTTPError
+	var soapFault *soap.SOAPFault
+	var urlErr *url.Error
+	var transportErr *TransportError
+	var wsaaErr *WsaaError
+	switch {
+	case errors.As(err, &transportErr), errors.As(err, &wsaaErr):
+		return err
+	case errors.As(err, &soapFault):
+		return newSoapFaultError(info, soapFault.Code, soapFault.String)
+	case errors.As(err, &httpErr):
+		fault := wsaaFault{}
+		if xml.Unmarshal(httpErr.ResponseBody, &fault) == nil && fault.Code != "" {
+			return newSoapFaultError(info, fault.Code, fault.String)
+		}
+		return &TransportError{ServiceName: info.serviceName, Operation: info.operation, StatusCode: httpErr.StatusCode, Err: err}
+	case errors.As(err, &urlErr):
+		return &TransportError{ServiceName: info.serviceName, Operation: info.operation, Err: err}
+	}
+	return err
+}
+
+func newSoapFaultError(info *operationInfo, code, message string) *SoapFaultError {
+	if i := strings.LastIndex(code, ":"); i >= 0 {
+		code = code[i+1:]
+	}
+	return &SoapFaultError{ServiceName: info.serviceName, Operation: info.operation, Code: strings.TrimSpace(code), Message: strings.TrimSpace(message)}
+}
+
+// businessError devuelve un *ArcaError si la respuesta de la operación de ctx informa errores de negocio o,
+// en modo estricto, comprobantes rechazados.
+func businessError(ctx context.Context, result any) error {
+	messages := arcaErrors(result)
+	strict := StrictFromContext(ctx)
+	if strict {
+		messages = append(messages, rejections(result)...)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	info, _ := ctx.Value(operationContextKey{}).(*operationInfo)
+	if info == nil {
+		info = &operationInfo{}
+	}
+	arcaErr := &ArcaError{ServiceName: info.serviceName, Operation: info.operation, Errors: messages}
+	if strict {
+		arcaErr.Warnings = Warnings(result)
+		arcaErr.Result = result
+	}
+	return arcaErr
+}
+
+// arcaErrors extrae los errores de negocio de una respuesta de wsfe, wscoem, wscoemcons o wgestabref.
+func arcaErrors(result any) []ArcaMessage {
+	var messages []ArcaMessage
+	for _, field := range responseFields(result) {
+		switch errs := field.(type) {
+		case *wsfe.ArrayOfErr:
+			for _, e := range errs.Err {
+				if e != nil {
+					messages = append(messages, ArcaMessage{Code: strconv.Itoa(int(e.Code)), Message: e.Msg})
+				}
+			}
+		case *wscoem.ArrayOfDgaMensajeSistemaReducido:
+			// El código 0 es informativo (por ejemplo, el identificador asignado).
+			for _, e := range errs.DetalleError {
+				if e != nil && e.Codigo != nil && *e.Codigo != 0 {
+					messages = append(messages, ArcaMessage{Code: strconv.Itoa(int(*e.Codigo)), Message: strings.TrimSpace(e.Descripcion + " " + e.DescripcionAdicional)})
+				}
+			}
+		case *wscoemcons.ArrayOfErrorEjecucion:
+			for _, e := range errs.ErrorEjecucion {
+				if e != nil {
+					messages = append(messages, ArcaMessage{Code: e.Codigo, Message: e.Descripcion})
+				}
+			}
+		case *wgestabref.Contenedor:
+			if errs.CodError != 0 {
+				messages = append(messages, ArcaMessage{Code: strconv.Itoa(int(errs.CodError)), Message: errs.InfoAdicional})
+			}
+		}
+	}
+	return messages
+}
+
+// responseFields devuelve los campos puntero no nulos de una respuesta, incluidos los de las estructuras
+// embebidas: en wscoem los errores están en RegistrarEmbarqueRta → ResponseAbstract → ResultadoEjecucionSerializable.
+func responseFields(result any) []any {
+	var fields []any
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		v = reflect.Indirect(v)
+		if v.Kind() != reflect.Struct {
+			return
+		}
+		t := v.Type()
+		for i := range v.NumField() {
+			field := v.Field(i)
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			if field.Kind() == reflect.Pointer && !field.IsNil() {
+				fields = append(fields, field.Interface())
+			}
+			if t.Field(i).Anonymous && (field.Kind() == reflect.Struct || (field.Kind() == reflect.Pointer && !field.IsNil())) {
+				walk(field)
+			}
+		}
+	}
+	if v := reflect.ValueOf(result); v.IsValid() && (v.Kind() != reflect.Pointer || !v.IsNil()) {
+		walk(v)
+	}
+	return fields
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hooklift/gowsdl/soap"
+	"github.com/sehogas/goarca/ws/wgestabref"
+	"github.com/sehogas/goarca/ws/wscoem"
+	"github.com/sehogas/goarca/ws/wscoemcons"
+	"github.com/sehogas/goarca/ws/wsfe"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func TestArcaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		result any
+		want   []ArcaMessage
+	}{
+		{
+			name: "wsfe",
+			result: &wsfe.FERecuperaLastCbteResponse{Errors: &wsfe.ArrayOfErr{Err: []*wsfe.Err{
+				{Code: 10015, Msg: "Factura B"}, nil,
+			}}},
+			want: []ArcaMessage{{Code: "10015", Message: "Factura B"}},
+		},
+		{
+			name: "wscoem",
+			result: &wscoem.RegistrarEmbarqueRta{ResponseAbstract: &wscoem.ResponseAbstract{
+				ResultadoEjecucionSerializable: &wscoem.ResultadoEjecucionSerializable{
+					ListaErrores: &wscoem.ArrayOfDgaMensajeSistemaReducido{DetalleError: []*wscoem.DgaMensajeSistemaReducido{
+						{Codigo: int32Ptr(0), Descripcion: "Identificador", DescripcionAdicional: "26001COEM000001A"},
+						{Codigo: int32Ptr(5), Descripcion: "Caratula inexistente"},
+						nil,
+					}},
+				},
+			}},
+			want: []ArcaMessage{{Code: "5", Message: "Caratula inexistente"}},
+		},
+		{
+			name: "wscoemcons",
+			result: &wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso{Errores: &wscoemcons.ArrayOfErrorEjecucion{
+				ErrorEjecucion: []*wscoemcons.ErrorEjecucion{{Codigo: "1001", Descripcion: "Sin datos"}, nil},
+			}},
+			want: []ArcaMessage{{Code: "1001", Message: "Sin datos"}},
+		},
+		{
+			name:   "wgestabref",
+			result: &wgestabref.FechaUltAct{Contenedor: &wgestabref.Contenedor{CodError: 300, InfoAdicional: "Referencia inexistente"}},
+			want:   []ArcaMessage{{Code: "300", Message: "Referencia inexistente"}},
+		},
+		{name: "sin errores", result: &wscoem.RegistrarEmbarqueRta{ResponseAbstract: &wscoem.ResponseAbstract{}}},
+		{name: "nil", result: (*wscoem.RegistrarEmbarqueRta)(nil)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := arcaErrors(test.result); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("arcaErrors() = %v, se esperaba %v", got, test.want)
+			}
+		})
+	}
+}
+
+// TestArcaErrorsWscoemResponse decodifica una respuesta de wscoem tal como la envía ARCA.
+func TestArcaErrorsWscoemResponse(t *testing.T) {
+	const body = `<RegistrarCaratulaResponse xmlns="Ar.Gob.Afip.Dga.wgescomunicacionembarque">
+		<RegistrarCaratulaResult>
+			<ListaErrores>
+				<DetalleError><Codigo>5</Codigo><Descripcion>Caratula inexistente</Descripcion><DescripcionAdicional>26001</DescripcionAdicional></DetalleError>
+			</ListaErrores>
+			<Server>srv1</Server>
+		</RegistrarCaratulaResult>
+	</RegistrarCaratulaResponse>`
+	var response wscoem.RegistrarCaratulaResponse
+	if err := xml.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := withOperationTimeout(context.Background(), "wscoem", "RegistrarCaratula")
+	defer cancel()
+	err := businessError(ctx, response.RegistrarCaratulaResult)
+	var arcaErr *ArcaError
+	if !errors.As(err, &arcaErr) {
+		t.Fatalf("businessError() = %v, se esperaba *ArcaError", err)
+	}
+	want := []ArcaMessage{{Code: "5", Message: "Caratula inexistente 26001"}}
+	if !reflect.DeepEqual(arcaErr.Errors, want) || arcaErr.Operation != "RegistrarCaratula" {
+		t.Errorf("businessError() = %+v, se esperaba %v", arcaErr, want)
+	}
+}
+
+func TestParseWsaaError(t *testing.T) {
+	refused := errors.New("dial tcp 127.0.0.1:443: connect: connection refused")
+	tests := []struct {
+		name       string
+		err        error
+		want       any
+		statusCode int
+		code       string
+	}{
+		{
+			name: "falla SOAP en respuesta HTTP 500",
+			err: &soap.HTTPError{StatusCode: 500, ResponseBody: []byte(`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body>` +
+				`<soapenv:Fault><faultcode xmlns:ns1="http://xml.apache.org/axis/">ns1:coe.alreadyAuthenticated</faultcode>` +
+				`<faultstring>El CEE ya posee un TA valido para el acceso al WSN solicitado</faultstring></soapenv:Fault></soapenv:Body></soapenv:Envelope>`)},
+			want: &WsaaError{},
+			code: WsaaAlreadyAuthenticated,
+		},
+		{
+			name:       "HTTP 503 sin falla SOAP",
+			err:        &soap.HTTPError{StatusCode: 503, ResponseBody: []byte("<html>Service Unavailable</html>")},
+			want:       &TransportError{},
+			statusCode: 503,
+		},
+		{
+			name: "error de conexión",
+			err:  &url.Error{Op: "Post", URL: "https://wsaahomo.afip.gov.ar/ws/services/LoginCms", Err: refused},
+			want: &TransportError{},
+		},
+		{
+			name: "otro error",
+			err:  refused,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := parseWsaaError(test.err)
+			if !errors.Is(err, test.err) && test.code == "" {
+				t.Errorf("parseWsaaError() = %v no conserva el error original", err)
+			}
+			switch test.want.(type) {
+			case *WsaaError:
+				var wsaaErr *WsaaError
+				if !errors.As(err, &wsaaErr) || wsaaErr.Code != test.code {
+					t.Errorf("parseWsaaError() = %v, se esperaba *WsaaError %s", err, test.code)
+				}
+			case *TransportError:
+				var transportErr *TransportError
+				if !errors.As(err, &transportErr) || transportErr.ServiceName != "wsaa" || transportErr.StatusCode != test.statusCode {
+					t.Fatalf("parseWsaaError() = %v, se esperaba *TransportError de wsaa con HTTP %d", err, test.statusCode)
+				}
+				// Al obtener el ticket para otra operación se conserva el error de WSAA.
+				ctx, cancel := withOperationTimeout(context.Background(), "wsfe", "FECAESolicitar")
+				defer cancel()
+				if classified := operationError(ctx, err); classified != err {
+					t.Errorf("operationError() = %v, se esperaba el error de WSAA", classified)
+				}
+			default:
+				var transportErr *TransportError
+				if errors.As(err, &transportErr) {
+					t.Errorf("parseWsaaError() = %v, no se esperaba *TransportError", err)
+				}
+			}
+		})
+	}
+}
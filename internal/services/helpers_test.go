@@ -0,0 +1,163 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"html"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testTenant devuelve un tenant con una clave RSA y un certificado autofirmado generados para la prueba.
+func testTenant(t testing.TB, cuit int64) *Tenant {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "goarca", SerialNumber: "CUIT " + strconv.FormatInt(cuit, 10)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificateFile := filepath.Join(t.TempDir(), "certificado.pem")
+	if err := os.WriteFile(certificateFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return &Tenant{Cuit: cuit, CertificateFile: certificateFile, Signer: key}
+}
+
+// testExchange devuelve un intercambio de FECAESolicitar con las credenciales del ticket en el sobre.
+func testExchange(cuit int64) *Exchange {
+	return &Exchange{
+		RequestID:   "abc123",
+		ServiceName: "wsfe",
+		Operation:   "FECAESolicitar",
+		Cuit:        cuit,
+		Attempt:     1,
+		Method:      http.MethodPost,
+		URL:         "https://wswhomo.afip.gov.ar/wsfev1/service.asmx",
+		StartedAt:   time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		RequestBody: []byte("<Auth><Token>secreto</Token><Sign>firma</Sign></Auth>"),
+		StatusCode:  http.StatusOK,
+	}
+}
+
+// fakeArca es un servidor local con WSAA y wsfev1, configurado como los servicios de homologación. LoginCms
+// responde un ticket nuevo en cada llamada; las operaciones de wsfev1 responden el resultado registrado con
+// respond (FEDummy responde OK). Mientras down es verdadero responde 503.
+type fakeArca struct {
+	server    *httptest.Server
+	logins    atomic.Int32
+	calls     atomic.Int32
+	downCalls atomic.Int32
+	down      atomic.Bool
+	// delay es la espera antes de responder LoginCms.
+	delay time.Duration
+
+	mu      sync.Mutex
+	results map[string]func(body []byte) string
+}
+
+// newFakeArca levanta el servidor y lo configura como WSAA y wsfev1 de homologación.
+func newFakeArca(t testing.TB) *fakeArca {
+	t.Helper()
+	f := newFakeArcaServer(t, httptest.NewServer)
+	if err := SetServiceURLs(TESTING, map[string]string{EndpointWSAA: f.server.URL, EndpointWSFE: f.server.URL}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetServiceURLs(TESTING, nil) })
+	return f
+}
+
+// newFakeArcaTLS es newFakeArca sobre TLS, con el certificado del servidor entre las CA de confianza.
+func newFakeArcaTLS(t testing.TB) *fakeArca {
+	t.Helper()
+	f := newFakeArcaServer(t, httptest.NewTLSServer)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.server.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetTLSOptions(TLSOptions{CAFiles: []string{caFile}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetTLSOptions(TLSOptions{}) })
+	if err := SetServiceURLs(TESTING, map[string]string{EndpointWSAA: f.server.URL, EndpointWSFE: f.server.URL}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetServiceURLs(TESTING, nil) })
+	return f
+}
+
+func newFakeArcaServer(t testing.TB, start func(http.Handler) *httptest.Server) *fakeArca {
+	f := &fakeArca{results: map[string]func([]byte) string{
+		"FEDummy": func([]byte) string {
+			return "<AppServer>OK</AppServer><DbServer>OK</DbServer><AuthServer>OK</AuthServer>"
+		},
+	}}
+	f.server = start(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// respond registra el contenido de <operationResult> de la operación de wsfev1.
+func (f *fakeArca) respond(operation string, result func(body []byte) string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[operation] = result
+}
+
+func (f *fakeArca) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.calls.Add(1)
+	if f.down.Load() {
+		f.downCalls.Add(1)
+		http.Error(w, "servicio no disponible", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+
+	if bytes.Contains(body, []byte("loginCms")) {
+		n := f.logins.Add(1)
+		time.Sleep(f.delay)
+		now := time.Now()
+		ticket := fmt.Sprintf(`<loginTicketResponse version="1.0"><header><generationTime>%s</generationTime><expirationTime>%s</expirationTime></header><credentials><token>token%d</token><sign>sign%d</sign></credentials></loginTicketResponse>`,
+			now.Format(time.RFC3339), now.Add(12*time.Hour).Format(time.RFC3339), n, n)
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><loginCmsResponse xmlns="http://wsaa.view.sua.dvadac.desein.afip.gov"><loginCmsReturn>%s</loginCmsReturn></loginCmsResponse></soapenv:Body></soapenv:Envelope>`,
+			html.EscapeString(ticket))
+		return
+	}
+
+	operation := path.Base(strings.Trim(r.Header.Get("SOAPAction"), `"`))
+	f.mu.Lock()
+	result, exist := f.results[operation]
+	f.mu.Unlock()
+	if !exist {
+		http.Error(w, "operación no soportada: "+operation, http.StatusBadRequest)
+		return
+	}
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>`+
+		`<%sResponse xmlns="http://ar.gov.afip.dif.FEV1/"><%sResult>%s</%sResult></%sResponse></soap:Body></soap:Envelope>`,
+		operation, operation, result(body), operation, operation)
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RedactionRule oculta el contenido de los elementos XML Element en los intercambios registrados en el log o
+// guardados. Keep conserva los últimos caracteres del valor (p. ej. los últimos 4 dígitos de un documento).
+type RedactionRule struct {
+	Element string
+	Keep    int
+}
+
+// DefaultRedactionRules ocultan las credenciales del ticket de acceso. Se aplican siempre.
+var DefaultRedactionRules = []RedactionRule{{Element: "Token"}, {Element: "Sign"}}
+
+const redactedValue = "***"
+
+var (
+	redactionMu       sync.RWMutex
+	redactionPatterns = compileRedactionRules(DefaultRedactionRules)
+)
+
+type redactionPattern struct {
+	rule    RedactionRule
+	pattern *regexp.Regexp
+}
+
+var xmlNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// wsaaCredentialsPattern alcanza el token y la firma del ticket de acceso, que WSAA devuelve como XML escapado
+// dentro de loginCmsReturn. Se ocultan siempre, como DefaultRedactionRules.
+var wsaaCredentialsPattern = regexp.MustCompile(`(&lt;(?:token|sign)&gt;)[^&<]+(&lt;/)`)
+
+// ParseRedactionRules interpreta reglas con el formato Elemento[:caracteres a conservar], p. ej. "DocNro:4".
+func ParseRedactionRules(values []string) ([]RedactionRule, error) {
+	var rules []RedactionRule
+	for _, value := range values {
+		element, keep, found := strings.Cut(strings.TrimSpace(value), ":")
+		rule := RedactionRule{Element: element}
+		if found {
+			var err error
+			rule.Keep, err = strconv.Atoi(keep)
+			if err != nil || rule.Keep < 0 {
+				return nil, fmt.Errorf("regla de ocultamiento inválida: %s", value)
+			}
+		}
+		if !xmlNamePattern.MatchString(rule.Element) {
+			return nil, fmt.Errorf("regla de ocultamiento inválida: %s", value)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// SetRedactionRules agrega reglas de ocultamiento a DefaultRedactionRules.
+func SetRedactionRules(rules []RedactionRule) error {
+	for _, rule := range rules {
+		if !xmlNamePattern.MatchString(rule.Element) || rule.Keep < 0 {
+			return fmt.Errorf("SetRedactionRules: regla inválida: %s", rule.Element)
+		}
+	}
+	patterns := compileRedactionRules(append(DefaultRedactionRules, rules...))
+
+	redactionMu.Lock()
+	defer redactionMu.Unlock()
+	redactionPatterns = patterns
+	return nil
+}
+
+// compileRedactionRules genera la expresión de cada regla: el elemento con cualquier prefijo de espacio de
+// nombres y atributos, y su contenido de texto.
+func compileRedactionRules(rules []RedactionRule) []redactionPattern {
+	var patterns []redactionPattern
+	for _, rule := range rules {
+		patterns = append(patterns, redactionPattern{
+			rule:    rule,
+			pattern: regexp.MustCompile(`(<(?:[A-Za-z_][\w.-]*:)?` + regexp.QuoteMeta(rule.Element) + `(?:\s[^>]*)?>)([^<]*)(</)`),
+		})
+	}
+	return patterns
+}
+
+// redactXML oculta en data el contenido de los elementos alcanzados por las reglas vigentes.
+func redactXML(data []byte) []byte {
+	redactionMu.RLock()
+	patterns := redactionPatterns
+	redactionMu.RUnlock()
+	return redactXMLWith(patterns, data)
+}
+
+func redactXMLWith(patterns []redactionPattern, data []byte) []byte {
+	data = redactWsaaCredentials(data)
+	for _, p := range patterns {
+		keep := p.rule.Keep
+		data = p.pattern.ReplaceAllFunc(data, func(match []byte) []byte {
+			groups := p.pattern.FindSubmatch(match)
+			value := groups[2]
+			if len(value) == 0 {
+				return match
+			}
+			masked := redactedValue
+			if keep > 0 && len(value) > keep {
+				masked = strings.Repeat("*", len(value)-keep) + string(value[len(value)-keep:])
+			}
+			return append(append(append([]byte{}, groups[1]...), masked...), groups[3]...)
+		})
+	}
+	return data
+}
+
+// redactWsaaCredentials oculta en data las credenciales del ticket de acceso emitido por WSAA.
+func redactWsaaCredentials(data []byte) []byte {
+	return wsaaCredentialsPattern.ReplaceAll(data, []byte("${1}"+redactedValue+"${2}"))
+}
+
+// redactExchange devuelve una copia del intercambio con los cuerpos ocultados.
+func redactExchange(exchange *Exchange) *Exchange {
+	redacted := *exchange
+	redacted.RequestBody = redactXML(exchange.RequestBody)
+	redacted.ResponseBody = redactXML(exchange.ResponseBody)
+	return &redacted
+}
+
+// encryptedAuditMagic identifica los intercambios sin ocultar cifrados con AES-256-GCM: magic, nonce y texto cifrado.
+const encryptedAuditMagic = "GOARCA-ENC1\n"
+
+// ParseAuditKey decodifica la clave de cifrado de los intercambios sin ocultar: 32 bytes en hexadecimal o base64.
+func ParseAuditKey(text []byte) ([]byte, error) {
+	value := strings.TrimSpace(string(text))
+	if key, err := hex.DecodeString(value); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(value); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, errors.New("la clave de cifrado debe tener 32 bytes en hexadecimal o base64")
+}
+
+func encryptAuditObject(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(encryptedAuditMagic)+gcm.NonceSize(), len(encryptedAuditMagic)+gcm.NonceSize()+len(data)+gcm.Overhead())
+	copy(out, encryptedAuditMagic)
+	nonce := out[len(encryptedAuditMagic):]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(out, nonce, data, nil), nil
+}
+
+// DecryptAuditObject descifra un intercambio sin ocultar guardado con AuditOptions.RawKey.
+func DecryptAuditObject(key, data []byte) ([]byte, error) {
+	if !strings.HasPrefix(string(data), encryptedAuditMagic) {
+		return nil, errors.New("DecryptAuditObject: el objeto no está cifrado")
+	}
+	data = data[len(encryptedAuditMagic):]
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("DecryptAuditObject: objeto truncado")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("DecryptAuditObject: %s", err)
+	}
+	return plain, nil
+}
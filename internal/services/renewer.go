@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+)
+
+// RenewalStatus es el estado de la renovación en segundo plano del ticket de un servicio.
+type RenewalStatus struct {
+	ServiceName    string    `json:"service"`
+	Cuit           int64     `json:"cuit"`
+	ExpirationTime time.Time `json:"expirationTime,omitzero"`
+	LastRenewal    time.Time `json:"lastRenewal,omitzero"`
+	NextAttempt    time.Time `json:"nextAttempt,omitzero"`
+	Failures       int       `json:"failures"`
+	LastError      string    `json:"lastError,omitempty"`
+}
+
+// RenewalTarget es un servicio de un tenant cuyo ticket se renueva en segundo plano.
+type RenewalTarget struct {
+	Tenant      *Tenant
+	ServiceName string
+}
+
+func (t RenewalTarget) key() string {
+	return ticketKey(t.ServiceName, t.Tenant.Cuit)
+}
+
+// TicketRenewer renueva en segundo plano los tickets de acceso de los servicios habilitados
+// un margen antes de su vencimiento, de modo que ninguna solicitud tenga que esperar a WSAA.
+type TicketRenewer struct {
+	logger      *slog.Logger
+	manager     *TicketManager
+	environment Environment
+	targets     []RenewalTarget
+	margin      time.Duration
+	jitter      time.Duration
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+
+	mu     sync.Mutex
+	status map[string]*RenewalStatus
+}
+
+// NewTicketRenewer crea un renovador para los servicios indicados que utiliza el administrador de tickets por defecto.
+// margin es la anticipación con la que se renueva cada ticket y jitter el desvío aleatorio máximo que se le resta.
+func NewTicketRenewer(logger *slog.Logger, environment Environment, targets []RenewalTarget, margin, jitter time.Duration) *TicketRenewer {
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	}
+
+	status := make(map[string]*RenewalStatus, len(targets))
+	for _, target := range targets {
+		status[target.key()] = &RenewalStatus{ServiceName: target.ServiceName, Cuit: target.Tenant.Cuit}
+	}
+
+	return &TicketRenewer{
+		logger:      logger,
+		manager:     defaultTicketManager,
+		environment: environment,
+		targets:     targets,
+		margin:      margin,
+		jitter:      jitter,
+		minBackoff:  30 * time.Second,
+		maxBackoff:  15 * time.Minute,
+		status:      status,
+	}
+}
+
+// Start lanza la renovación de cada servicio hasta que ctx se cancele.
+func (r *TicketRenewer) Start(ctx context.Context) {
+	for _, target := range r.targets {
+		go r.run(ctx, target)
+	}
+}
+
+// Status devuelve el estado de renovación de cada servicio.
+func (r *TicketRenewer) Status() []RenewalStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]RenewalStatus, 0, len(r.targets))
+	for _, target := range r.targets {
+		result = append(result, *r.status[target.key()])
+	}
+	return result
+}
+
+func (r *TicketRenewer) run(ctx context.Context, target RenewalTarget) {
+	serviceName, cuit := target.ServiceName, target.Tenant.Cuit
+	backoff := r.minBackoff
+	for {
+		var wait time.Duration
+		ticket, err := r.manager.Ensure(r.environment, serviceName, target.Tenant, r.margin)
+		if err != nil {
+			wait = backoff + randDuration(backoff/2)
+			backoff = min(backoff*2, r.maxBackoff)
+
+			current, exist := r.manager.Cached(serviceName, cuit)
+			if exist && current.ExpirationTime.After(time.Now()) {
+				r.logger.Error("No se pudo renovar el ticket de acceso; el ticket actual sigue vigente",
+					"service", serviceName, "cuit", cuit, "expiration", current.ExpirationTime, "retry", wait, "err", err.Error())
+			} else {
+				r.logger.Error("No se pudo renovar el ticket de acceso",
+					"service", serviceName, "cuit", cuit, "retry", wait, "err", err.Error())
+			}
+			r.recordFailure(target, err, time.Now().Add(wait))
+		} else {
+			backoff = r.minBackoff
+			wait = max(time.Until(ticket.ExpirationTime)-r.margin-randDuration(r.jitter), r.minBackoff)
+			r.logger.Debug("Ticket de acceso vigente", "service", serviceName, "cuit", cuit, "expiration", ticket.ExpirationTime, "next", wait)
+			r.recordSuccess(target, ticket, time.Now().Add(wait))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *TicketRenewer) recordSuccess(target RenewalTarget, ticket *LoginTicket, next time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := r.status[target.key()]
+	if !status.ExpirationTime.Equal(ticket.ExpirationTime) {
+		status.LastRenewal = time.Now()
+	}
+	status.ExpirationTime = ticket.ExpirationTime
+	status.NextAttempt = next
+	status.Failures = 0
+	status.LastError = ""
+}
+
+func (r *TicketRenewer) recordFailure(target RenewalTarget, err error, next time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := r.status[target.key()]
+	status.NextAttempt = next
+	status.Failures++
+	status.LastError = err.Error()
+}
+
+// randDuration devuelve una duración aleatoria en [0, d).
+func randDuration(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy define los reintentos de las operaciones SOAP ante fallas transitorias de ARCA.
+type RetryPolicy struct {
+	// MaxRetries es la cantidad de reintentos por defecto de cada operación.
+	MaxRetries int
+	// BaseDelay es la espera antes del primer reintento; se duplica en cada uno, con variación aleatoria.
+	BaseDelay time.Duration
+	// MaxDelay es la espera máxima entre reintentos.
+	MaxDelay time.Duration
+	// Operations define reintentos específicos por operación, con clave "servicio.Operacion" u "Operacion".
+	Operations map[string]int
+}
+
+// DefaultRetryPolicy es la política utilizada si no se llama a SetRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 2, BaseDelay: 500 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+var (
+	retryPolicyMu sync.RWMutex
+	retryPolicy   = DefaultRetryPolicy
+)
+
+// SetRetryPolicy define la política de reintentos de las operaciones SOAP.
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicyMu.Lock()
+	defer retryPolicyMu.Unlock()
+	retryPolicy = policy
+}
+
+// operationRetries devuelve la cantidad de reintentos y las esperas configuradas para la operación.
+func operationRetries(serviceName, operation string) (int, time.Duration, time.Duration) {
+	retryPolicyMu.RLock()
+	defer retryPolicyMu.RUnlock()
+	retries := retryPolicy.MaxRetries
+	if value, exist := retryPolicy.Operations[operation]; exist {
+		retries = value
+	}
+	if value, exist := retryPolicy.Operations[serviceName+"."+operation]; exist {
+		retries = value
+	}
+	return retries, retryPolicy.BaseDelay, retryPolicy.MaxDelay
+}
+
+// safeOperation indica si la operación sólo consulta datos y puede repetirse sin efectos en ARCA.
+func safeOperation(operation string) bool {
+	for _, prefix := range []string{"FEParamGet", "FECompConsultar", "FECompUltimoAutorizado", "FECompTotXRequest", "Lista", "Obtener", "Consultar"} {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(operation, "Dummy") || strings.HasSuffix(operation, "Consultar")
+}
+
+// soapOperation obtiene el nombre de la operación del header SOAPAction ("http://ar.gov.afip.dif.FEV1/FEDummy").
+func soapOperation(req *http.Request) string {
+	action := strings.Trim(req.Header.Get("SOAPAction"), `"`)
+	return action[strings.LastIndex(action, "/")+1:]
+}
+
+// retryTransport reintenta las solicitudes SOAP que fallan por causas transitorias. Las operaciones que
+// modifican datos (por ejemplo FECAESolicitar o RegistrarCOEM) sólo se reintentan si no se pudo establecer
+// la conexión, ya que en cualquier otro caso ARCA pudo haberlas procesado.
+type retryTransport struct {
+	serviceName string
+	base        http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	operation := soapOperation(req)
+	retries, baseDelay, maxDelay := operationRetries(t.serviceName, operation)
+	safe := safeOperation(operation)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		res, err := t.base.RoundTrip(req)
+		if attempt >= retries || req.Context().Err() != nil {
+			return res, err
+		}
+		var retryAfter time.Duration
+		if err != nil {
+			if !transientError(err, safe) {
+				return res, err
+			}
+		} else {
+			var transient bool
+			res, transient = transientResponse(res, safe)
+			if !transient {
+				return res, nil
+			}
+			retryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		delay := backoff(attempt, baseDelay, maxDelay)
+		if retryAfter > delay {
+			delay = min(retryAfter, maxDelay)
+		}
+		cause := "HTTP " + strconv.Itoa(statusCode(res))
+		if err != nil {
+			cause = err.Error()
+		}
+		log.Printf("Reintentando %s.%s en %s (%d/%d): %s\n", t.serviceName, operation, delay, attempt+1, retries, cause)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// transientError indica si el error de conexión justifica un reintento. Si la operación no es segura,
+// sólo se reintenta cuando no se llegó a establecer la conexión.
+func transientError(err error, safe bool) bool {
+	var certificateErr *tls.CertificateVerificationError
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &certificateErr) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	return safe
+}
+
+// transientResponse indica si la respuesta es una falla transitoria del servidor. Las operaciones que no son
+// seguras nunca se reintentan una vez recibida una respuesta. Devuelve la respuesta con el cuerpo intacto.
+func transientResponse(res *http.Response, safe bool) (*http.Response, bool) {
+	if !safe {
+		return res, false
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return res, true
+	case http.StatusInternalServerError:
+		// Los SOAP faults se informan con HTTP 500: sólo se reintentan los de servidor ocupado o no disponible,
+		// además de las páginas de error que no son SOAP.
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return res, true
+		}
+		text := strings.ToLower(string(body))
+		if !strings.Contains(text, "fault>") {
+			return res, true
+		}
+		for _, message := range []string{"too busy", "timeout", "timed out", "unavailable", "temporarily"} {
+			if strings.Contains(text, message) {
+				return res, true
+			}
+		}
+	}
+	return res, false
+}
+
+// backoff devuelve la espera antes del reintento attempt+1: exponencial, con una variación aleatoria de hasta la mitad.
+func backoff(attempt int, baseDelay, maxDelay time.Duration) time.Duration {
+	if baseDelay <= 0 {
+		return 0
+	}
+	delay := baseDelay << min(attempt, 16)
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+func statusCode(res *http.Response) int {
+	if res == nil {
+		return 0
+	}
+	return res.StatusCode
+}
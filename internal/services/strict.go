@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/sehogas/goarca/ws/wscoem"
+	"github.com/sehogas/goarca/ws/wsfe"
+)
+
+// Resultados de los comprobantes de wsfe.
+const (
+	ResultadoAprobado  = "A"
+	ResultadoRechazado = "R"
+	ResultadoParcial   = "P"
+)
+
+type strictContextKey struct{}
+
+// ContextWithStrict indica en ctx si la solicitud opera en modo estricto: los comprobantes rechazados o
+// aprobados parcialmente se informan como *ArcaError, junto con las advertencias de la respuesta.
+func ContextWithStrict(ctx context.Context, strict bool) context.Context {
+	return context.WithValue(ctx, strictContextKey{}, strict)
+}
+
+// StrictFromContext indica si la solicitud de ctx opera en modo estricto.
+func StrictFromContext(ctx context.Context) bool {
+	strict, _ := ctx.Value(strictContextKey{}).(bool)
+	return strict
+}
+
+// rejections devuelve las observaciones de los comprobantes rechazados si el resultado de la respuesta
+// de wsfe es rechazado (R) o parcial (P).
+func rejections(result any) []ArcaMessage {
+	var cab *wsfe.FECabResponse
+	var details []*wsfe.FEDetResponse
+	switch r := result.(type) {
+	case *wsfe.FECAEResponse:
+		if r.FeCabResp != nil {
+			cab = r.FeCabResp.FECabResponse
+		}
+		if r.FeDetResp != nil {
+			for _, det := range r.FeDetResp.FECAEDetResponse {
+				if det != nil {
+					details = append(details, det.FEDetResponse)
+				}
+			}
+		}
+	case *wsfe.FECAEAResponse:
+		if r.FeCabResp != nil {
+			cab = r.FeCabResp.FECabResponse
+		}
+		if r.FeDetResp != nil {
+			for _, det := range r.FeDetResp.FECAEADetResponse {
+				if det != nil {
+					details = append(details, det.FEDetResponse)
+				}
+			}
+		}
+	}
+	if cab == nil || (cab.Resultado != ResultadoRechazado && cab.Resultado != ResultadoParcial) {
+		return nil
+	}
+
+	var messages []ArcaMessage
+	for _, det := range details {
+		if det == nil || det.Resultado != ResultadoRechazado {
+			continue
+		}
+		observaciones := observations(det.Observaciones)
+		if len(observaciones) == 0 {
+			observaciones = []ArcaMessage{{Code: ResultadoRechazado, Message: "comprobante rechazado sin observaciones"}}
+		}
+		for _, m := range observaciones {
+			m.Message = "Cbte " + strconv.FormatInt(det.CbteDesde, 10) + ": " + m.Message
+			messages = append(messages, m)
+		}
+	}
+	if len(messages) == 0 {
+		messages = append(messages, ArcaMessage{Code: cab.Resultado, Message: "solicitud rechazada sin observaciones"})
+	}
+	return messages
+}
+
+// Warnings devuelve los mensajes que no impiden el procesamiento de la solicitud: los eventos (Events) y
+// las observaciones de los comprobantes aprobados de wsfe, y los mensajes informativos (código 0) de wscoem.
+func Warnings(result any) []ArcaMessage {
+	var messages []ArcaMessage
+	for _, field := range responseFields(result) {
+		switch value := field.(type) {
+		case *wsfe.ArrayOfEvt:
+			for _, e := range value.Evt {
+				if e != nil {
+					messages = append(messages, ArcaMessage{Code: strconv.Itoa(int(e.Code)), Message: e.Msg})
+				}
+			}
+		case *wsfe.ArrayOfFECAEDetResponse:
+			for _, det := range value.FECAEDetResponse {
+				if det != nil && det.FEDetResponse != nil && det.Resultado != ResultadoRechazado {
+					messages = append(messages, observations(det.Observaciones)...)
+				}
+			}
+		case *wsfe.ArrayOfFECAEADetResponse:
+			for _, det := range value.FECAEADetResponse {
+				if det != nil && det.FEDetResponse != nil && det.Resultado != ResultadoRechazado {
+					messages = append(messages, observations(det.Observaciones)...)
+				}
+			}
+		case *wsfe.FECompConsResponse:
+			messages = append(messages, observations(value.Observaciones)...)
+		case *wsfe.FECAEAGet:
+			messages = append(messages, observations(value.Observaciones)...)
+		case *wscoem.ArrayOfDgaMensajeSistemaReducido:
+			for _, e := range value.DetalleError {
+				if e != nil && (e.Codigo == nil || *e.Codigo == 0) {
+					messages = append(messages, ArcaMessage{Code: "0", Message: strings.TrimSpace(e.Descripcion + " " + e.DescripcionAdicional)})
+				}
+			}
+		}
+	}
+	return messages
+}
+
+func observations(list *wsfe.ArrayOfObs) []ArcaMessage {
+	if list == nil {
+		return nil
+	}
+	messages := make([]ArcaMessage, 0, len(list.Obs))
+	for _, o := range list.Obs {
+		if o != nil {
+			messages = append(messages, ArcaMessage{Code: strconv.Itoa(int(o.Code)), Message: o.Msg})
+		}
+	}
+	return messages
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sehogas/goarca/ws/wgestabref"
+	"github.com/sehogas/goarca/ws/wscoem"
+	"github.com/sehogas/goarca/ws/wscoemcons"
+	"github.com/sehogas/goarca/ws/wsfe"
+)
+
+func TestStrictBusinessError(t *testing.T) {
+	tests := []struct {
+		name         string
+		result       any
+		wantErrors   []ArcaMessage
+		wantWarnings []ArcaMessage
+	}{
+		{
+			name: "wsfe rechazado",
+			result: &wsfe.FECAEResponse{
+				FeCabResp: &wsfe.FECAECabResponse{FECabResponse: &wsfe.FECabResponse{Resultado: ResultadoRechazado}},
+				FeDetResp: &wsfe.ArrayOfFECAEDetResponse{FECAEDetResponse: []*wsfe.FECAEDetResponse{
+					{FEDetResponse: &wsfe.FEDetResponse{CbteDesde: 7, Resultado: ResultadoRechazado, Observaciones: &wsfe.ArrayOfObs{Obs: []*wsfe.Obs{{Code: 10016, Msg: "fecha inválida"}}}}},
+				}},
+				Events: &wsfe.ArrayOfEvt{Evt: []*wsfe.Evt{{Code: 35, Msg: "mantenimiento"}}},
+			},
+			wantErrors:   []ArcaMessage{{Code: "10016", Message: "Cbte 7: fecha inválida"}},
+			wantWarnings: []ArcaMessage{{Code: "35", Message: "mantenimiento"}},
+		},
+		{
+			name: "wsfe aprobado parcialmente",
+			result: &wsfe.FECAEResponse{
+				FeCabResp: &wsfe.FECAECabResponse{FECabResponse: &wsfe.FECabResponse{Resultado: ResultadoParcial}},
+				FeDetResp: &wsfe.ArrayOfFECAEDetResponse{FECAEDetResponse: []*wsfe.FECAEDetResponse{
+					{FEDetResponse: &wsfe.FEDetResponse{CbteDesde: 8, CbteHasta: 8, Resultado: ResultadoAprobado}, CAE: "75123456789012", CAEFchVto: "20261026"},
+					{FEDetResponse: &wsfe.FEDetResponse{CbteDesde: 9, CbteHasta: 9, Resultado: ResultadoRechazado, Observaciones: &wsfe.ArrayOfObs{Obs: []*wsfe.Obs{{Code: 10013, Msg: "documento inválido"}}}}},
+				}},
+			},
+			wantErrors: []ArcaMessage{{Code: "10013", Message: "Cbte 9: documento inválido"}},
+		},
+		{
+			name: "wscoem",
+			result: &wscoem.RegistrarEmbarqueRta{ResponseAbstract: &wscoem.ResponseAbstract{
+				ResultadoEjecucionSerializable: &wscoem.ResultadoEjecucionSerializable{
+					ListaErrores: &wscoem.ArrayOfDgaMensajeSistemaReducido{DetalleError: []*wscoem.DgaMensajeSistemaReducido{
+						{Codigo: int32Ptr(0), Descripcion: "Identificador", DescripcionAdicional: "26001COEM000001A"},
+						{Codigo: int32Ptr(12), Descripcion: "Buque inexistente"},
+					}},
+				},
+			}},
+			wantErrors:   []ArcaMessage{{Code: "12", Message: "Buque inexistente"}},
+			wantWarnings: []ArcaMessage{{Code: "0", Message: "Identificador 26001COEM000001A"}},
+		},
+		{
+			name: "wscoemcons",
+			result: &wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso{Errores: &wscoemcons.ArrayOfErrorEjecucion{
+				ErrorEjecucion: []*wscoemcons.ErrorEjecucion{{Codigo: "1001", Descripcion: "Sin datos"}},
+			}},
+			wantErrors: []ArcaMessage{{Code: "1001", Message: "Sin datos"}},
+		},
+		{
+			name:       "wgestabref",
+			result:     &wgestabref.FechaUltAct{Contenedor: &wgestabref.Contenedor{CodError: 300, InfoAdicional: "Referencia inexistente"}},
+			wantErrors: []ArcaMessage{{Code: "300", Message: "Referencia inexistente"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := ContextWithStrict(context.Background(), true)
+			var arcaErr *ArcaError
+			if err := businessError(ctx, test.result); !errors.As(err, &arcaErr) {
+				t.Fatalf("businessError() = %v, se esperaba *ArcaError", err)
+			}
+			if !reflect.DeepEqual(arcaErr.Errors, test.wantErrors) {
+				t.Errorf("Errors = %v, se esperaba %v", arcaErr.Errors, test.wantErrors)
+			}
+			if !reflect.DeepEqual(arcaErr.Warnings, test.wantWarnings) {
+				t.Errorf("Warnings = %v, se esperaba %v", arcaErr.Warnings, test.wantWarnings)
+			}
+			if arcaErr.Result != test.result {
+				t.Errorf("Result = %v, se esperaba la respuesta de ARCA", arcaErr.Result)
+			}
+		})
+	}
+}
+
+// TestStrictPartialApproval verifica que en una aprobación parcial el error conserva los CAE otorgados,
+// que ARCA ya registró y no vuelve a emitir.
+func TestStrictPartialApproval(t *testing.T) {
+	result := &wsfe.FECAEResponse{
+		FeCabResp: &wsfe.FECAECabResponse{FECabResponse: &wsfe.FECabResponse{Resultado: ResultadoParcial}},
+		FeDetResp: &wsfe.ArrayOfFECAEDetResponse{FECAEDetResponse: []*wsfe.FECAEDetResponse{
+			{FEDetResponse: &wsfe.FEDetResponse{CbteDesde: 8, CbteHasta: 8, Resultado: ResultadoAprobado}, CAE: "75123456789012", CAEFchVto: "20261026"},
+			{FEDetResponse: &wsfe.FEDetResponse{CbteDesde: 9, CbteHasta: 9, Resultado: ResultadoRechazado}},
+		}},
+	}
+	ctx, cancel := withOperationTimeout(ContextWithStrict(context.Background(), true), "wsfe", "FECAESolicitar")
+	defer cancel()
+
+	var arcaErr *ArcaError
+	if err := businessError(ctx, result); !errors.As(err, &arcaErr) {
+		t.Fatalf("businessError() = %v, se esperaba *ArcaError", err)
+	}
+	data, err := json.Marshal(arcaErr.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response wsfe.FECAEResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	var approved []string
+	for _, det := range response.FeDetResp.FECAEDetResponse {
+		if det.Resultado == ResultadoAprobado {
+			approved = append(approved, det.CAE)
+		}
+	}
+	if !reflect.DeepEqual(approved, []string{"75123456789012"}) {
+		t.Errorf("CAE aprobados en el error = %v, se esperaba [75123456789012]", approved)
+	}
+
+	// Fuera del modo estricto la aprobación parcial no es un error.
+	if err := businessError(context.Background(), result); err != nil {
+		t.Errorf("businessError() sin modo estricto = %v, se esperaba nil", err)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		result any
+		want   []ArcaMessage
+	}{
+		{
+			name: "wsfe aprobado con observaciones",
+			result: &wsfe.FECAEResponse{
+				FeCabResp: &wsfe.FECAECabResponse{FECabResponse: &wsfe.FECabResponse{Resultado: ResultadoAprobado}},
+				FeDetResp: &wsfe.ArrayOfFECAEDetResponse{FECAEDetResponse: []*wsfe.FECAEDetResponse{
+					{FEDetResponse: &wsfe.FEDetResponse{Resultado: ResultadoAprobado, Observaciones: &wsfe.ArrayOfObs{Obs: []*wsfe.Obs{{Code: 10217, Msg: "observación"}}}}},
+					nil,
+				}},
+			},
+			want: []ArcaMessage{{Code: "10217", Message: "observación"}},
+		},
+		{
+			name: "wscoem mensajes informativos",
+			result: &wscoem.RegistrarEmbarqueRta{ResponseAbstract: &wscoem.ResponseAbstract{
+				ResultadoEjecucionSerializable: &wscoem.ResultadoEjecucionSerializable{
+					ListaErrores: &wscoem.ArrayOfDgaMensajeSistemaReducido{DetalleError: []*wscoem.DgaMensajeSistemaReducido{
+						{Codigo: int32Ptr(0), Descripcion: "Identificador", DescripcionAdicional: "26001COEM000001A"},
+						nil,
+					}},
+				},
+			}},
+			want: []ArcaMessage{{Code: "0", Message: "Identificador 26001COEM000001A"}},
+		},
+		{name: "wscoemcons sin advertencias", result: &wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso{}},
+		{name: "wgestabref sin advertencias", result: &wgestabref.FechaUltAct{Contenedor: &wgestabref.Contenedor{}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Warnings(test.result); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Warnings() = %v, se esperaba %v", got, test.want)
+			}
+			// Sin errores ni rechazos, el modo estricto no rechaza la respuesta.
+			if err := businessError(ContextWithStrict(context.Background(), true), test.result); err != nil {
+				t.Errorf("businessError() = %v, se esperaba nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sehogas/goarca/internal/util"
+)
+
+// AgentRole es el tipo de agente y rol con el que un CUIT opera los servicios aduaneros.
+type AgentRole struct {
+	TipoAgente string `json:"tipoAgente"`
+	Rol        string `json:"rol"`
+}
+
+func (r *AgentRole) validate(name string) error {
+	if len(r.TipoAgente) != 4 {
+		return fmt.Errorf("missing or invalid %s tipoAgente", name)
+	}
+	if len(r.Rol) != 4 {
+		return fmt.Errorf("missing or invalid %s rol", name)
+	}
+	return nil
+}
+
+// Tenant son los datos con los que un CUIT se autentica y opera ante ARCA.
+type Tenant struct {
+	Cuit int64  `json:"cuit"`
+	Name string `json:"name,omitempty"`
+	// PrivateKeyFile puede ser un PEM (PKCS#1, PKCS#8 o EC, cifrado o no) o un PKCS#12 (.p12/.pfx)
+	// que contenga también el certificado; en ese caso CertificateFile es opcional.
+	PrivateKeyFile  string `json:"privateKeyFile"`
+	CertificateFile string `json:"certificateFile,omitempty"`
+	// La frase de paso de la clave se lee de PrivateKeyPassphraseFile o, si no se indica,
+	// de la variable de entorno cuyo nombre es PrivateKeyPassphraseEnv.
+	PrivateKeyPassphraseFile string `json:"privateKeyPassphraseFile,omitempty"`
+	PrivateKeyPassphraseEnv  string `json:"privateKeyPassphraseEnv,omitempty"`
+	// PKCS11 indica que la clave está en un token PKCS#11 en lugar de PrivateKeyFile.
+	// Requiere CertificateFile y un binario compilado con -tags pkcs11.
+	PKCS11 *util.PKCS11Config `json:"pkcs11,omitempty"`
+	// Signer permite a quien use el paquete delegar la firma en otro proceso o dispositivo.
+	// Tiene prioridad sobre PKCS11 y PrivateKeyFile y requiere CertificateFile.
+	Signer crypto.Signer `json:"-"`
+	// Wscoem se utiliza para wgescomunicacionembarque y wconscomunicacionembarque.
+	Wscoem     *AgentRole `json:"wscoem,omitempty"`
+	Wgestabref *AgentRole `json:"wgestabref,omitempty"`
+}
+
+func (t *Tenant) validate() error {
+	if t.Cuit <= 0 {
+		return fmt.Errorf("missing or invalid cuit [%d]", t.Cuit)
+	}
+	if t.usesKeyFile() {
+		if _, err := os.Stat(t.PrivateKeyFile); err != nil {
+			return fmt.Errorf("CUIT %d privateKeyFile: %s", t.Cuit, err)
+		}
+	} else if t.CertificateFile == "" {
+		return fmt.Errorf("CUIT %d: missing certificateFile", t.Cuit)
+	}
+	if t.CertificateFile != "" {
+		if _, err := os.Stat(t.CertificateFile); err != nil {
+			return fmt.Errorf("CUIT %d certificateFile: %s", t.Cuit, err)
+		}
+	}
+	_, certificate, err := t.KeyPair()
+	if err != nil {
+		return fmt.Errorf("CUIT %d: %s", t.Cuit, err)
+	}
+	if err := util.CheckCertificate(certificate, t.Cuit, time.Now()); err != nil {
+		return fmt.Errorf("CUIT %d: %s", t.Cuit, err)
+	}
+	if t.Wscoem != nil {
+		if err := t.Wscoem.validate("wscoem"); err != nil {
+			return fmt.Errorf("CUIT %d: %s", t.Cuit, err)
+		}
+	}
+	if t.Wgestabref != nil {
+		if err := t.Wgestabref.validate("wgestabref"); err != nil {
+			return fmt.Errorf("CUIT %d: %s", t.Cuit, err)
+		}
+	}
+	return nil
+}
+
+// usesKeyFile indica si la clave privada se lee de PrivateKeyFile (el caso por defecto).
+func (t *Tenant) usesKeyFile() bool {
+	return t.Signer == nil && t.PKCS11 == nil
+}
+
+// KeyPair devuelve el firmante y el certificado del tenant y verifica que se correspondan.
+// Se leen en cada llamada para que un certificado renovado se tome sin reiniciar el servidor.
+func (t *Tenant) KeyPair() (crypto.Signer, *x509.Certificate, error) {
+	if !t.usesKeyFile() {
+		signer := t.Signer
+		if signer == nil {
+			var err error
+			if signer, err = util.OpenPKCS11Signer(t.PKCS11); err != nil {
+				return nil, nil, err
+			}
+		}
+		certificate, err := util.ReadCertificate(t.CertificateFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("certificado [%s]: %s", t.CertificateFile, err)
+		}
+		if certificate == nil {
+			return nil, nil, fmt.Errorf("certificado [%s]: no se encontró un certificado", t.CertificateFile)
+		}
+		if err := util.CheckKeyPair(signer, certificate); err != nil {
+			return nil, nil, err
+		}
+		return signer, certificate, nil
+	}
+
+	passphrase, err := util.ReadPassphrase(t.PrivateKeyPassphraseFile, t.PrivateKeyPassphraseEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return util.LoadKeyPair(t.PrivateKeyFile, t.CertificateFile, passphrase)
+}
+
+// TenantFromEnv arma el único tenant configurado mediante las variables de entorno
+// CUIT, PRIVATE_KEY_FILE, CERTIFICATE_FILE, PRIVATE_KEY_PASSPHRASE_FILE, PRIVATE_KEY_PASSPHRASE,
+// WSCOEM_TIPO_AGENTE, WSCOEM_ROL, WGESTABREF_TIPO_AGENTE y WGESTABREF_ROL. Si se define PKCS11_MODULE
+// la clave se toma del token indicado por PKCS11_TOKEN_LABEL, PKCS11_KEY_LABEL, PKCS11_KEY_ID,
+// PKCS11_PIN_FILE y PKCS11_PIN en lugar de PRIVATE_KEY_FILE.
+func TenantFromEnv() (*Tenant, error) {
+	cuit, err := strconv.ParseInt(os.Getenv("CUIT"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("missing or invalid environment variable CUIT")
+	}
+	pkcs11Module := os.Getenv("PKCS11_MODULE")
+	if os.Getenv("PRIVATE_KEY_FILE") == "" && pkcs11Module == "" {
+		return nil, fmt.Errorf("missing environment variable PRIVATE_KEY_FILE")
+	}
+	if os.Getenv("CERTIFICATE_FILE") == "" && (pkcs11Module != "" || !util.IsPKCS12File(os.Getenv("PRIVATE_KEY_FILE"))) {
+		return nil, fmt.Errorf("missing environment variable CERTIFICATE_FILE")
+	}
+
+	tenant := &Tenant{
+		Cuit:                     cuit,
+		PrivateKeyFile:           os.Getenv("PRIVATE_KEY_FILE"),
+		CertificateFile:          os.Getenv("CERTIFICATE_FILE"),
+		PrivateKeyPassphraseFile: os.Getenv("PRIVATE_KEY_PASSPHRASE_FILE"),
+		PrivateKeyPassphraseEnv:  "PRIVATE_KEY_PASSPHRASE",
+	}
+	if pkcs11Module != "" {
+		tenant.PKCS11 = &util.PKCS11Config{
+			Module:     pkcs11Module,
+			TokenLabel: os.Getenv("PKCS11_TOKEN_LABEL"),
+			KeyLabel:   os.Getenv("PKCS11_KEY_LABEL"),
+			KeyID:      os.Getenv("PKCS11_KEY_ID"),
+			PinFile:    os.Getenv("PKCS11_PIN_FILE"),
+			PinEnv:     "PKCS11_PIN",
+		}
+	}
+	if os.Getenv("WSCOEM_TIPO_AGENTE") != "" || os.Getenv("WSCOEM_ROL") != "" {
+		tenant.Wscoem = &AgentRole{
+			TipoAgente: os.Getenv("WSCOEM_TIPO_AGENTE"),
+			Rol:        os.Getenv("WSCOEM_ROL"),
+		}
+	}
+	if os.Getenv("WGESTABREF_TIPO_AGENTE") != "" || os.Getenv("WGESTABREF_ROL") != "" {
+		tenant.Wgestabref = &AgentRole{
+			TipoAgente: os.Getenv("WGESTABREF_TIPO_AGENTE"),
+			Rol:        os.Getenv("WGESTABREF_ROL"),
+		}
+	}
+	return tenant, nil
+}
+
+// TenantRegistry contiene los CUIT habilitados para operar mediante el servidor.
+type TenantRegistry struct {
+	tenants map[int64]*Tenant
+	cuits   []int64
+}
+
+func NewTenantRegistry(tenants ...*Tenant) (*TenantRegistry, error) {
+	registry := &TenantRegistry{
+		tenants: make(map[int64]*Tenant, len(tenants)),
+	}
+	for _, tenant := range tenants {
+		if err := tenant.validate(); err != nil {
+			return nil, err
+		}
+		if _, exist := registry.tenants[tenant.Cuit]; exist {
+			return nil, fmt.Errorf("CUIT %d duplicado", tenant.Cuit)
+		}
+		registry.tenants[tenant.Cuit] = tenant
+		registry.cuits = append(registry.cuits, tenant.Cuit)
+	}
+	if len(registry.cuits) == 0 {
+		return nil, fmt.Errorf("no hay CUIT configurados")
+	}
+	sort.Slice(registry.cuits, func(i, j int) bool { return registry.cuits[i] < registry.cuits[j] })
+	return registry, nil
+}
+
+// LoadTenants lee el registro desde un archivo JSON con la lista de tenants.
+func LoadTenants(fileName string) (*TenantRegistry, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("LoadTenants: %s", err)
+	}
+
+	var tenants []*Tenant
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("LoadTenants: %s", err)
+	}
+	return NewTenantRegistry(tenants...)
+}
+
+func (r *TenantRegistry) Get(cuit int64) (*Tenant, bool) {
+	tenant, exist := r.tenants[cuit]
+	return tenant, exist
+}
+
+// All devuelve los tenants ordenados por CUIT.
+func (r *TenantRegistry) All() []*Tenant {
+	result := make([]*Tenant, 0, len(r.cuits))
+	for _, cuit := range r.cuits {
+		result = append(result, r.tenants[cuit])
+	}
+	return result
+}
+
+type tenantContextKey struct{}
+
+// ContextWithTenant devuelve una copia de ctx asociada al tenant de la solicitud.
+func ContextWithTenant(ctx context.Context, tenant *Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext devuelve el tenant asociado a ctx.
+func TenantFromContext(ctx context.Context) (*Tenant, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(*Tenant)
+	return tenant, ok
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+var defaultTicketManager = NewTicketManager(&FileTicketStore{dir: "data"})
+
+// SetTicketStore reemplaza el almacenamiento de tickets del administrador por defecto.
+func SetTicketStore(store TicketStore) {
+	defaultTicketManager.SetStore(store)
+}
+
+// SetAlreadyAuthenticatedWait define cuánto espera el administrador por defecto a que otra réplica
+// almacene el ticket cuando WSAA rechaza el login con coe.alreadyAuthenticated.
+func SetAlreadyAuthenticatedWait(wait time.Duration) {
+	defaultTicketManager.mu.Lock()
+	defer defaultTicketManager.mu.Unlock()
+	defaultTicketManager.alreadyAuthWait = wait
+}
+
+// InspectTA devuelve el estado del ticket de acceso del CUIT para el servicio, sin token ni firma.
+func InspectTA(serviceName string, cuit int64) (*TicketInfo, error) {
+	return defaultTicketManager.Inspect(context.Background(), serviceName, cuit)
+}
+
+// InvalidateTA descarta el ticket de acceso del CUIT para el servicio, en memoria y en el almacenamiento.
+func InvalidateTA(serviceName string, cuit int64) error {
+	return defaultTicketManager.Invalidate(context.Background(), serviceName, cuit)
+}
+
+// RenewTA solicita a WSAA un nuevo ticket de acceso aunque el actual siga vigente.
+func RenewTA(environment Environment, serviceName string, tenant *Tenant) (*LoginTicket, error) {
+	return defaultTicketManager.Renew(environment, serviceName, tenant)
+}
+
+// TicketInfo es el estado de un ticket de acceso, sin el token ni la firma.
+type TicketInfo struct {
+	ServiceName    string
+	Cuit           int64
+	GenerationTime time.Time
+	ExpirationTime time.Time
+	// Source indica de dónde se obtuvo el ticket: "memory" o el almacenamiento ("file", "sql" o "redis").
+	Source string
+	Valid  bool
+	// BlockedUntil es el momento hasta el que WSAA rechazará un nuevo login (coe.alreadyAuthenticated).
+	BlockedUntil time.Time
+}
+
+// TicketManager administra los tickets de acceso (TA) emitidos por WSAA.
+// Es seguro para uso concurrente: mientras un ticket se renueva, el resto de
+// las solicitudes del mismo servicio esperan el resultado de esa única renovación.
+type TicketManager struct {
+	store           TicketStore
+	alreadyAuthWait time.Duration
+	mu              sync.Mutex
+	tickets         map[string]*LoginTicket
+	inflight        map[string]*ticketCall
+	// blocked registra hasta cuándo WSAA rechazará un nuevo login por servicio (coe.alreadyAuthenticated).
+	blocked map[string]time.Time
+}
+
+// ticketKey identifica un ticket por CUIT y servicio.
+func ticketKey(serviceName string, cuit int64) string {
+	return fmt.Sprintf("%d/%s", cuit, serviceName)
+}
+
+// ticketCall representa una obtención de ticket en curso.
+type ticketCall struct {
+	done   chan struct{}
+	ticket *LoginTicket
+	err    error
+}
+
+// NewTicketManager crea un administrador de tickets que persiste los tickets en store.
+func NewTicketManager(store TicketStore) *TicketManager {
+	return &TicketManager{
+		store:    store,
+		tickets:  make(map[string]*LoginTicket),
+		inflight: make(map[string]*ticketCall),
+		blocked:  make(map[string]time.Time),
+	}
+}
+
+// Get devuelve el ticket de acceso vigente para el servicio. Si no existe o está vencido,
+// lo obtiene del almacenamiento o solicita uno nuevo a WSAA.
+// Si ctx finaliza antes, la solicitud a WSAA continúa en segundo plano y se devuelve ctx.Err().
+func (m *TicketManager) Get(ctx context.Context, environment Environment, serviceName string, tenant *Tenant) (*LoginTicket, error) {
+	return m.ensure(ctx, environment, serviceName, tenant, 0, false)
+}
+
+// Ensure devuelve un ticket de acceso que siga vigente al menos durante minValidity,
+// renovándolo si el ticket en memoria o el almacenado vencen antes.
+func (m *TicketManager) Ensure(environment Environment, serviceName string, tenant *Tenant, minValidity time.Duration) (*LoginTicket, error) {
+	return m.ensure(context.Background(), environment, serviceName, tenant, minValidity, false)
+}
+
+// Renew solicita a WSAA un nuevo ticket aunque el actual siga vigente, por ejemplo después de
+// rotar el certificado. Si WSAA lo rechaza, se conserva el ticket actual.
+func (m *TicketManager) Renew(environment Environment, serviceName string, tenant *Tenant) (*LoginTicket, error) {
+	return m.ensure(context.Background(), environment, serviceName, tenant, 0, true)
+}
+
+func (m *TicketManager) ensure(ctx context.Context, environment Environment, serviceName string, tenant *Tenant, minValidity time.Duration, force bool) (*LoginTicket, error) {
+	key := ticketKey(serviceName, tenant.Cuit)
+
+	m.mu.Lock()
+	if ticket, exist := m.tickets[key]; exist && !force && ticket.validFor(minValidity) {
+		m.mu.Unlock()
+		return ticket, nil
+	}
+	if call, exist := m.inflight[key]; exist {
+		m.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if force || (call.err == nil && !call.ticket.validFor(minValidity)) {
+			return m.ensure(ctx, environment, serviceName, tenant, minValidity, force)
+		}
+		return call.ticket, call.err
+	}
+	call := &ticketCall{done: make(chan struct{})}
+	m.inflight[key] = call
+	m.mu.Unlock()
+
+	// La solicitud no depende de ctx: el ticket sirve también a las demás solicitudes que lo esperan.
+	go func() {
+		call.ticket, call.err = m.obtain(environment, serviceName, tenant, minValidity, force)
+
+		m.mu.Lock()
+		if call.err == nil {
+			m.tickets[key] = call.ticket
+		}
+		delete(m.inflight, key)
+		m.mu.Unlock()
+		close(call.done)
+	}()
+
+	select {
+	case <-call.done:
+		return call.ticket, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Cached devuelve el ticket en memoria del CUIT para el servicio, aunque esté vencido.
+func (m *TicketManager) Cached(serviceName string, cuit int64) (*LoginTicket, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ticket, exist := m.tickets[ticketKey(serviceName, cuit)]
+	return ticket, exist
+}
+
+// Inspect devuelve el estado del ticket en memoria o, si no hay, del almacenado.
+// Devuelve ErrTicketNotFound si no existe ninguno.
+func (m *TicketManager) Inspect(ctx context.Context, serviceName string, cuit int64) (*TicketInfo, error) {
+	key := ticketKey(serviceName, cuit)
+
+	m.mu.Lock()
+	store := m.store
+	ticket, exist := m.tickets[key]
+	blockedUntil := m.blocked[key]
+	m.mu.Unlock()
+
+	source := "memory"
+	if !exist {
+		var err error
+		if ticket, err = store.Load(ctx, serviceName, cuit); err != nil {
+			return nil, err
+		}
+		source = ticketStoreName(store)
+	}
+
+	info := &TicketInfo{
+		ServiceName:    serviceName,
+		Cuit:           cuit,
+		GenerationTime: ticket.GenerationTime,
+		ExpirationTime: ticket.ExpirationTime,
+		Source:         source,
+		Valid:          ticket.validFor(0),
+	}
+	if time.Now().Before(blockedUntil) {
+		info.BlockedUntil = blockedUntil
+	}
+	return info, nil
+}
+
+// Invalidate descarta el ticket en memoria y en el almacenamiento. El próximo uso del servicio
+// solicitará un nuevo ticket a WSAA.
+func (m *TicketManager) Invalidate(ctx context.Context, serviceName string, cuit int64) error {
+	m.mu.Lock()
+	store := m.store
+	delete(m.tickets, ticketKey(serviceName, cuit))
+	m.mu.Unlock()
+
+	if err := store.Delete(ctx, serviceName, cuit); err != nil && !errors.Is(err, ErrTicketNotFound) {
+		return err
+	}
+	log.Printf("Ticket de acceso descartado para [%s] CUIT [%d]\n", serviceName, cuit)
+	return nil
+}
+
+// SetStore reemplaza el almacenamiento persistente y descarta los tickets en memoria.
+func (m *TicketManager) SetStore(store TicketStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+	m.tickets = make(map[string]*LoginTicket)
+}
+
+// obtain lee el ticket almacenado y, si no existe o está vencido, genera uno nuevo.
+// Con force no se consulta el almacenamiento ni se respeta el bloqueo por coe.alreadyAuthenticated.
+func (m *TicketManager) obtain(environment Environment, serviceName string, tenant *Tenant, minValidity time.Duration, force bool) (*LoginTicket, error) {
+	key := ticketKey(serviceName, tenant.Cuit)
+
+	m.mu.Lock()
+	store := m.store
+	blockedUntil := m.blocked[key]
+	m.mu.Unlock()
+
+	ctx := context.Background()
+	var ticket *LoginTicket
+	var err error
+	if !force {
+		ticket, err = store.Load(ctx, serviceName, tenant.Cuit)
+		if err == nil && ticket.validFor(minValidity) {
+			return ticket, nil
+		}
+		if err != nil && !errors.Is(err, ErrTicketNotFound) {
+			log.Printf("Error leyendo ticket de acceso almacenado para [%s]: %s\n", serviceName, err)
+		}
+	}
+
+	if !force && time.Now().Before(blockedUntil) {
+		return nil, &TicketUnavailableError{
+			ServiceName: serviceName,
+			Cuit:        tenant.Cuit,
+			RetryAfter:  time.Until(blockedUntil),
+			Err:         ErrAlreadyAuthenticated,
+		}
+	}
+
+	if err != nil {
+		log.Printf("Intentando genera ticket de acceso para [%s] CUIT [%d]...\n", serviceName, tenant.Cuit)
+	} else {
+		log.Printf("Renovando ticket de acceso para [%s] CUIT [%d]...\n", serviceName, tenant.Cuit)
+	}
+	ticket, err = GenerarTA(environment, serviceName, tenant)
+	if errors.Is(err, ErrAlreadyAuthenticated) && force {
+		blockedUntil = time.Now().Add(wsaaAlreadyAuthenticatedWait)
+		m.mu.Lock()
+		m.blocked[key] = blockedUntil
+		m.mu.Unlock()
+		return nil, &TicketUnavailableError{
+			ServiceName: serviceName,
+			Cuit:        tenant.Cuit,
+			RetryAfter:  time.Until(blockedUntil),
+			Err:         err,
+		}
+	}
+	if errors.Is(err, ErrAlreadyAuthenticated) {
+		return m.waitStored(ctx, store, serviceName, tenant.Cuit, minValidity, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Ticket de acceso emitido para [%s] CUIT [%d]\n", serviceName, tenant.Cuit)
+
+	m.mu.Lock()
+	delete(m.blocked, key)
+	m.mu.Unlock()
+
+	if err := store.Save(ctx, ticket); err != nil {
+		log.Printf("Error almacenando ticket de acceso para [%s]: %s\n", serviceName, err)
+	}
+	return ticket, nil
+}
+
+// waitStored se invoca cuando WSAA ya emitió un ticket vigente que este proceso no tiene
+// (por ejemplo, lo obtuvo otra réplica o se perdió el almacenado). Espera, como máximo
+// alreadyAuthWait, a que el ticket aparezca en el almacenamiento compartido; si no aparece,
+// informa cuándo conviene reintentar.
+func (m *TicketManager) waitStored(ctx context.Context, store TicketStore, serviceName string, cuit int64, minValidity time.Duration, cause error) (*LoginTicket, error) {
+	blockedUntil := time.Now().Add(wsaaAlreadyAuthenticatedWait)
+
+	m.mu.Lock()
+	m.blocked[ticketKey(serviceName, cuit)] = blockedUntil
+	wait := m.alreadyAuthWait
+	m.mu.Unlock()
+
+	log.Printf("WSAA ya posee un ticket vigente para [%s]; esperando hasta %s a que se almacene\n", serviceName, wait)
+
+	deadline := time.Now().Add(wait)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(5*time.Second, time.Until(deadline))):
+		}
+
+		ticket, err := store.Load(ctx, serviceName, cuit)
+		if err == nil && ticket.validFor(minValidity) {
+			log.Printf("Ticket de acceso obtenido del almacenamiento para [%s]\n", serviceName)
+			return ticket, nil
+		}
+	}
+
+	return nil, &TicketUnavailableError{
+		ServiceName: serviceName,
+		Cuit:        cuit,
+		RetryAfter:  time.Until(blockedUntil),
+		Err:         cause,
+	}
+}
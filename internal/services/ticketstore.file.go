@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileTicketStore almacena cada ticket en un archivo JSON dentro de un directorio local.
+type FileTicketStore struct {
+	dir string
+}
+
+func NewFileTicketStore(dir string) (*FileTicketStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("NewFileTicketStore: %s", err)
+	}
+	return &FileTicketStore{dir: dir}, nil
+}
+
+func (s *FileTicketStore) fileName(serviceName string, cuit int64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s_%d.json", serviceName, cuit))
+}
+
+func (s *FileTicketStore) Load(ctx context.Context, serviceName string, cuit int64) (*LoginTicket, error) {
+	data, err := os.ReadFile(s.fileName(serviceName, cuit))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrTicketNotFound
+		}
+		return nil, err
+	}
+
+	var stored storedTicket
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("FileTicketStore: ticket inválido para [%s]: %s", serviceName, err)
+	}
+	return stored.loginTicket(), nil
+}
+
+// Save escribe el ticket de forma atómica (archivo temporal + rename) con permisos 0600.
+func (s *FileTicketStore) Save(ctx context.Context, ticket *LoginTicket) error {
+	data, err := json.MarshalIndent(newStoredTicket(ticket), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, ".ticket-*")
+	if err != nil {
+		return err
+	}
+	tmpName := f.Name()
+	defer os.Remove(tmpName)
+
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, s.fileName(ticket.ServiceName, ticket.Cuit))
+}
+
+func (s *FileTicketStore) Delete(ctx context.Context, serviceName string, cuit int64) error {
+	err := os.Remove(s.fileName(serviceName, cuit))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
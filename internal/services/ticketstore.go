@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrTicketNotFound = errors.New("ticket de acceso no encontrado")
+
+// TicketStore es el almacenamiento persistente de los tickets de acceso emitidos por WSAA.
+// Permite compartir los tickets entre réplicas del servidor para no volver a autenticarse
+// contra WSAA mientras exista un ticket vigente.
+type TicketStore interface {
+	// Load devuelve el ticket almacenado o ErrTicketNotFound si no existe.
+	Load(ctx context.Context, serviceName string, cuit int64) (*LoginTicket, error)
+	Save(ctx context.Context, ticket *LoginTicket) error
+	Delete(ctx context.Context, serviceName string, cuit int64) error
+}
+
+// storedTicket es la representación serializada de un ticket de acceso.
+type storedTicket struct {
+	ServiceName    string    `json:"service"`
+	Cuit           int64     `json:"cuit"`
+	Token          string    `json:"token"`
+	Sign           string    `json:"sign"`
+	GenerationTime time.Time `json:"generationTime,omitzero"`
+	ExpirationTime time.Time `json:"expirationTime"`
+}
+
+func newStoredTicket(ticket *LoginTicket) *storedTicket {
+	return &storedTicket{
+		ServiceName:    ticket.ServiceName,
+		Cuit:           ticket.Cuit,
+		Token:          ticket.Token,
+		Sign:           ticket.Sign,
+		GenerationTime: ticket.GenerationTime,
+		ExpirationTime: ticket.ExpirationTime,
+	}
+}
+
+func (t *storedTicket) loginTicket() *LoginTicket {
+	return &LoginTicket{
+		ServiceName:    t.ServiceName,
+		Token:          t.Token,
+		Sign:           t.Sign,
+		GenerationTime: t.GenerationTime,
+		ExpirationTime: t.ExpirationTime,
+		Cuit:           t.Cuit,
+	}
+}
+
+// ticketStoreName devuelve el nombre con el que se informa el origen de un ticket almacenado.
+func ticketStoreName(store TicketStore) string {
+	switch store.(type) {
+	case *FileTicketStore:
+		return "file"
+	case *SQLTicketStore:
+		return "sql"
+	case *RedisTicketStore:
+		return "redis"
+	default:
+		return "store"
+	}
+}
+
+// NewTicketStore crea el almacenamiento de tickets indicado por kind ("file", "sql" o "redis").
+// Para "file" dsn es el directorio, para "sql" el archivo de la base embebida y para "redis"
+// la URL de conexión (redis://[:password@]host:port[/db]).
+func NewTicketStore(kind, dsn string) (TicketStore, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", "file":
+		if dsn == "" {
+			dsn = "data"
+		}
+		return NewFileTicketStore(dsn)
+	case "sql", "sqlite":
+		if dsn == "" {
+			dsn = "data/tickets.db"
+		}
+		return NewSQLTicketStore(dsn)
+	case "redis":
+		return NewRedisTicketStore(dsn)
+	default:
+		return nil, fmt.Errorf("tipo de almacenamiento de tickets desconocido: %s", kind)
+	}
+}
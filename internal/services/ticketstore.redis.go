@@ -0,0 +1,187 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RedisTicketStore almacena los tickets en un servidor compatible con el protocolo de Redis (RESP).
+// Cada ticket se guarda con TTL igual a su vigencia, de modo que el servidor lo descarta al vencer.
+type RedisTicketStore struct {
+	addr     string
+	username string
+	password string
+	db       int
+	useTLS   bool
+	prefix   string
+	timeout  time.Duration
+}
+
+// NewRedisTicketStore crea el almacenamiento a partir de una URL redis://[user:password@]host:port[/db]
+// (rediss:// para conexiones TLS).
+func NewRedisTicketStore(rawURL string) (*RedisTicketStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("NewRedisTicketStore: URL inválida: %s", err)
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("NewRedisTicketStore: esquema no soportado [%s]", u.Scheme)
+	}
+
+	store := &RedisTicketStore{
+		addr:    u.Host,
+		useTLS:  u.Scheme == "rediss",
+		prefix:  "goarca:ta:",
+		timeout: 5 * time.Second,
+	}
+	if u.Port() == "" {
+		store.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		store.username = u.User.Username()
+		store.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		store.db, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("NewRedisTicketStore: base de datos inválida [%s]", db)
+		}
+	}
+	return store, nil
+}
+
+func (s *RedisTicketStore) key(serviceName string, cuit int64) string {
+	return fmt.Sprintf("%s%d:%s", s.prefix, cuit, serviceName)
+}
+
+func (s *RedisTicketStore) Load(ctx context.Context, serviceName string, cuit int64) (*LoginTicket, error) {
+	reply, err := s.do(ctx, "GET", s.key(serviceName, cuit))
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrTicketNotFound
+	}
+
+	var stored storedTicket
+	if err := json.Unmarshal(reply, &stored); err != nil {
+		return nil, fmt.Errorf("RedisTicketStore: ticket inválido para [%s]: %s", serviceName, err)
+	}
+	return stored.loginTicket(), nil
+}
+
+func (s *RedisTicketStore) Save(ctx context.Context, ticket *LoginTicket) error {
+	ttl := time.Until(ticket.ExpirationTime)
+	if ttl <= 0 {
+		return s.Delete(ctx, ticket.ServiceName, ticket.Cuit)
+	}
+
+	data, err := json.Marshal(newStoredTicket(ticket))
+	if err != nil {
+		return err
+	}
+	_, err = s.do(ctx, "SET", s.key(ticket.ServiceName, ticket.Cuit), string(data), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+func (s *RedisTicketStore) Delete(ctx context.Context, serviceName string, cuit int64) error {
+	_, err := s.do(ctx, "DEL", s.key(serviceName, cuit))
+	return err
+}
+
+// do abre una conexión, se autentica, selecciona la base y ejecuta el comando indicado.
+func (s *RedisTicketStore) do(ctx context.Context, args ...string) ([]byte, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	var conn net.Conn
+	var err error
+	if s.useTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", s.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RedisTicketStore: %s", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	if s.password != "" {
+		auth := []string{"AUTH", s.password}
+		if s.username != "" {
+			auth = []string{"AUTH", s.username, s.password}
+		}
+		if _, err := redisCommand(rw, auth...); err != nil {
+			return nil, err
+		}
+	}
+	if s.db != 0 {
+		if _, err := redisCommand(rw, "SELECT", strconv.Itoa(s.db)); err != nil {
+			return nil, err
+		}
+	}
+	return redisCommand(rw, args...)
+}
+
+// redisCommand envía un comando RESP y devuelve la respuesta. Una respuesta nula devuelve nil, nil.
+func redisCommand(rw *bufio.ReadWriter, args ...string) ([]byte, error) {
+	fmt.Fprintf(rw, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(rw, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := rw.Flush(); err != nil {
+		return nil, fmt.Errorf("RedisTicketStore: %s", err)
+	}
+	return redisReply(rw.Reader)
+}
+
+func redisReply(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("RedisTicketStore: %s", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("RedisTicketStore: respuesta vacía")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, fmt.Errorf("RedisTicketStore: %s", line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("RedisTicketStore: respuesta inválida [%s]", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, fmt.Errorf("RedisTicketStore: %s", err)
+		}
+		return buf[:size], nil
+	case '_':
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("RedisTicketStore: respuesta no soportada [%s]", line)
+	}
+}
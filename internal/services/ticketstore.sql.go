@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLTicketStore almacena los tickets en una base SQLite embebida.
+type SQLTicketStore struct {
+	db *sql.DB
+}
+
+func NewSQLTicketStore(fileName string) (*SQLTicketStore, error) {
+	db, err := sql.Open("sqlite", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("NewSQLTicketStore: %s", err)
+	}
+	// SQLite admite un único escritor; se serializa el acceso desde este proceso.
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS tickets (
+		service    TEXT    NOT NULL,
+		cuit       INTEGER NOT NULL,
+		token      TEXT    NOT NULL,
+		sign       TEXT    NOT NULL,
+		generation TEXT    NOT NULL DEFAULT '',
+		expiration TEXT    NOT NULL,
+		PRIMARY KEY (service, cuit)
+	)`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("NewSQLTicketStore: %s", err)
+	}
+	return &SQLTicketStore{db: db}, nil
+}
+
+func (s *SQLTicketStore) Load(ctx context.Context, serviceName string, cuit int64) (*LoginTicket, error) {
+	var token, sign, generation, expiration string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT token, sign, generation, expiration FROM tickets WHERE service = ? AND cuit = ?`,
+		serviceName, cuit).Scan(&token, &sign, &generation, &expiration)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTicketNotFound
+		}
+		return nil, err
+	}
+
+	expirationTime, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		return nil, fmt.Errorf("SQLTicketStore: fecha de expiración inválida para [%s]: %s", serviceName, err)
+	}
+	generationTime, _ := time.Parse(time.RFC3339, generation)
+	return &LoginTicket{
+		ServiceName:    serviceName,
+		Token:          token,
+		Sign:           sign,
+		GenerationTime: generationTime,
+		ExpirationTime: expirationTime,
+		Cuit:           cuit,
+	}, nil
+}
+
+func (s *SQLTicketStore) Save(ctx context.Context, ticket *LoginTicket) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO tickets (service, cuit, token, sign, generation, expiration) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (service, cuit) DO UPDATE SET token = excluded.token, sign = excluded.sign,
+			generation = excluded.generation, expiration = excluded.expiration`,
+		ticket.ServiceName, ticket.Cuit, ticket.Token, ticket.Sign, formatOptionalTime(ticket.GenerationTime), ticket.ExpirationTime.Format(time.RFC3339))
+	return err
+}
+
+func (s *SQLTicketStore) Delete(ctx context.Context, serviceName string, cuit int64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM tickets WHERE service = ? AND cuit = ?`, serviceName, cuit)
+	return err
+}
+
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
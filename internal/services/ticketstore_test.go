@@ -0,0 +1,247 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// redisStandIn es un servidor RESP en memoria con los comandos que utiliza RedisTicketStore.
+type redisStandIn struct {
+	listener net.Listener
+	mu       sync.Mutex
+	values   map[string]string
+	expires  map[string]time.Time
+}
+
+func newRedisStandIn(t testing.TB) *redisStandIn {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &redisStandIn{listener: listener, values: make(map[string]string), expires: make(map[string]time.Time)}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *redisStandIn) url() string {
+	return "redis://" + s.listener.Addr().String()
+}
+
+func (s *redisStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, n)
+		for i := range args {
+			r.ReadString('\n')
+			arg, _ := r.ReadString('\n')
+			args[i] = strings.TrimSuffix(arg, "\r\n")
+		}
+		io.WriteString(conn, s.execute(args))
+	}
+}
+
+func (s *redisStandIn) execute(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		value, exist := s.values[args[1]]
+		if expires, ok := s.expires[args[1]]; ok && time.Now().After(expires) {
+			delete(s.values, args[1])
+			exist = false
+		}
+		if !exist {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		s.values[args[1]] = args[2]
+		delete(s.expires, args[1])
+		if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+			ms, _ := strconv.Atoi(args[4])
+			s.expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "DEL":
+		_, exist := s.values[args[1]]
+		delete(s.values, args[1])
+		if exist {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+// ticketStores devuelve un almacenamiento vacío de cada tipo.
+func ticketStores(t *testing.T) map[string]TicketStore {
+	t.Helper()
+	file, err := NewFileTicketStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, err := NewSQLTicketStore(filepath.Join(t.TempDir(), "tickets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sql.db.Close() })
+	redis, err := NewRedisTicketStore(newRedisStandIn(t).url())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]TicketStore{"file": file, "sql": sql, "redis": redis}
+}
+
+func TestTicketStoreContract(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range ticketStores(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.Load(ctx, "wsfe", 20123456786); !errors.Is(err, ErrTicketNotFound) {
+				t.Fatalf("Load() inexistente = %v, se esperaba ErrTicketNotFound", err)
+			}
+
+			now := time.Now().Truncate(time.Second)
+			ticket := &LoginTicket{ServiceName: "wsfe", Cuit: 20123456786, Token: "token1", Sign: "sign1", GenerationTime: now, ExpirationTime: now.Add(12 * time.Hour)}
+			if err := store.Save(ctx, ticket); err != nil {
+				t.Fatal(err)
+			}
+			other := &LoginTicket{ServiceName: "wsfe", Cuit: 30999999995, Token: "token2", Sign: "sign2", ExpirationTime: now.Add(time.Hour)}
+			if err := store.Save(ctx, other); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := store.Load(ctx, "wsfe", 20123456786)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Token != "token1" || got.Sign != "sign1" || got.Cuit != 20123456786 || got.ServiceName != "wsfe" ||
+				!got.GenerationTime.Equal(now) || !got.ExpirationTime.Equal(ticket.ExpirationTime) {
+				t.Errorf("Load() = %+v, se esperaba %+v", got, ticket)
+			}
+			if _, err := store.Load(ctx, "wscoem", 20123456786); !errors.Is(err, ErrTicketNotFound) {
+				t.Errorf("Load() de otro servicio = %v, se esperaba ErrTicketNotFound", err)
+			}
+
+			// Un ticket nuevo reemplaza al anterior.
+			ticket.Token = "token3"
+			if err := store.Save(ctx, ticket); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := store.Load(ctx, "wsfe", 20123456786); err != nil || got.Token != "token3" {
+				t.Errorf("Load() después de reemplazar = %+v, %v", got, err)
+			}
+
+			if err := store.Delete(ctx, "wsfe", 20123456786); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Delete(ctx, "wsfe", 20123456786); err != nil {
+				t.Errorf("Delete() repetido = %v", err)
+			}
+			if _, err := store.Load(ctx, "wsfe", 20123456786); !errors.Is(err, ErrTicketNotFound) {
+				t.Errorf("Load() después de Delete = %v, se esperaba ErrTicketNotFound", err)
+			}
+			if got, err := store.Load(ctx, "wsfe", 30999999995); err != nil || got.Token != "token2" {
+				t.Errorf("Delete() afectó a otro CUIT: %+v, %v", got, err)
+			}
+
+			// Un ticket vencido no debe devolverse como vigente.
+			expired := &LoginTicket{ServiceName: "wsfe", Cuit: 20123456786, Token: "old", Sign: "old", ExpirationTime: now.Add(-time.Minute)}
+			if err := store.Save(ctx, expired); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := store.Load(ctx, "wsfe", 20123456786); err == nil && got.validFor(0) {
+				t.Errorf("Load() devolvió un ticket vencido como vigente: %+v", got)
+			} else if err != nil && !errors.Is(err, ErrTicketNotFound) {
+				t.Errorf("Load() vencido = %v", err)
+			}
+		})
+	}
+}
+
+// TestTicketManagerStoreDedup verifica con cada almacenamiento que las solicitudes concurrentes comparten un
+// único login a WSAA y que otra réplica reutiliza el ticket almacenado.
+func TestTicketManagerStoreDedup(t *testing.T) {
+	wsaa := newFakeArca(t)
+	wsaa.delay = 50 * time.Millisecond
+	tenant := testTenant(t, 20123456786)
+
+	for name, store := range ticketStores(t) {
+		t.Run(name, func(t *testing.T) {
+			wsaa.logins.Store(0)
+			manager := NewTicketManager(store)
+
+			var wg sync.WaitGroup
+			tokens := make([]string, 20)
+			for i := range tokens {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ticket, err := manager.Get(context.Background(), TESTING, "wsfe", tenant)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					tokens[i] = ticket.Token
+				}()
+			}
+			wg.Wait()
+			if n := wsaa.logins.Load(); n != 1 {
+				t.Fatalf("logins a WSAA = %d, se esperaba 1", n)
+			}
+			for _, token := range tokens {
+				if token != tokens[0] {
+					t.Fatalf("tokens distintos: %v", tokens)
+				}
+			}
+
+			// Otra réplica con el mismo almacenamiento no vuelve a autenticarse.
+			replica := NewTicketManager(store)
+			ticket, err := replica.Get(context.Background(), TESTING, "wsfe", tenant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ticket.Token != tokens[0] || wsaa.logins.Load() != 1 {
+				t.Errorf("la réplica obtuvo %s con %d logins, se esperaba %s sin nuevos logins", ticket.Token, wsaa.logins.Load(), tokens[0])
+			}
+
+			// Un ticket almacenado vencido se renueva.
+			if err := store.Save(context.Background(), &LoginTicket{ServiceName: "wscoem", Cuit: tenant.Cuit, Token: "old", Sign: "old", ExpirationTime: time.Now().Add(-time.Minute)}); err != nil {
+				t.Fatal(err)
+			}
+			ticket, err = replica.Get(context.Background(), TESTING, "wscoem", tenant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ticket.Token == "old" || wsaa.logins.Load() != 2 {
+				t.Errorf("ticket vencido no renovado: %s con %d logins", ticket.Token, wsaa.logins.Load())
+			}
+		})
+	}
+}
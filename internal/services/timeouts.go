@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultOperationTimeout es el tiempo máximo de una operación SOAP sin configuración específica.
+// Es menor que el WriteTimeout del servidor HTTP para poder responder el error al cliente.
+const DefaultOperationTimeout = 25 * time.Second
+
+var (
+	operationTimeoutsMu     sync.RWMutex
+	defaultOperationTimeout = DefaultOperationTimeout
+	operationTimeouts       = make(map[string]time.Duration)
+)
+
+// SetOperationTimeouts define el tiempo máximo por defecto de las operaciones SOAP y los tiempos específicos
+// por operación. Las claves de timeouts pueden ser "servicio.Operacion" (por ejemplo "wsfe.FECAESolicitar")
+// o sólo el nombre de la operación.
+func SetOperationTimeouts(defaultTimeout time.Duration, timeouts map[string]time.Duration) {
+	operationTimeoutsMu.Lock()
+	defer operationTimeoutsMu.Unlock()
+	if defaultTimeout > 0 {
+		defaultOperationTimeout = defaultTimeout
+	}
+	operationTimeouts = make(map[string]time.Duration, len(timeouts))
+	for operation, timeout := range timeouts {
+		operationTimeouts[operation] = timeout
+	}
+}
+
+// OperationTimeout devuelve el tiempo máximo configurado para la operación del servicio.
+func OperationTimeout(serviceName, operation string) time.Duration {
+	operationTimeoutsMu.RLock()
+	defer operationTimeoutsMu.RUnlock()
+	if timeout, exist := operationTimeouts[serviceName+"."+operation]; exist {
+		return timeout
+	}
+	if timeout, exist := operationTimeouts[operation]; exist {
+		return timeout
+	}
+	return defaultOperationTimeout
+}
+
+// TimeoutError indica que la operación no finalizó dentro del tiempo configurado.
+type TimeoutError struct {
+	ServiceName string
+	Operation   string
+	Timeout     time.Duration
+	Err         error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s: %s no respondió dentro de %s", e.Operation, e.ServiceName, e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+type operationContextKey struct{}
+
+type operationInfo struct {
+	serviceName string
+	operation   string
+	timeout     time.Duration
+	// requestID identifica la solicitud a la API en los intercambios capturados; attempts cuenta sus intentos.
+	requestID string
+	attempts  atomic.Int32
+}
+
+// withOperationTimeout limita ctx al tiempo máximo configurado para la operación.
+func withOperationTimeout(ctx context.Context, serviceName, operation string) (context.Context, context.CancelFunc) {
+	info := &operationInfo{serviceName: serviceName, operation: operation, timeout: OperationTimeout(serviceName, operation), requestID: RequestIDFromContext(ctx)}
+	if info.requestID == "" {
+		// Operaciones sin solicitud a la API, por ejemplo la prueba del circuit breaker.
+		info.requestID = NewRequestID()
+	}
+	ctx = context.WithValue(ctx, operationContextKey{}, info)
+	return context.WithTimeout(ctx, info.timeout)
+}
+
+// operationError devuelve un TimeoutError si la operación de ctx venció su tiempo máximo, el CircuitOpenError
+// si no se llamó a ARCA por tener el circuito abierto, o el error del cliente SOAP clasificado como
+// SoapFaultError o TransportError. Los demás errores se devuelven sin cambios.
+func operationError(ctx context.Context, err error) error {
+	var circuitErr *CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		return circuitErr
+	}
+	info, _ := ctx.Value(operationContextKey{}).(*operationInfo)
+	if err == nil || info == nil || errors.Is(ctx.Err(), context.Canceled) {
+		return err
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &TimeoutError{ServiceName: info.serviceName, Operation: info.operation, Timeout: info.timeout, Err: err}
+	}
+	return classifyError(info, err)
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// TLSOptions configura la verificación de los certificados de los servidores de ARCA.
+type TLSOptions struct {
+	// CAFiles son bundles PEM que se agregan a las CA del sistema (por ejemplo, las de homologación).
+	CAFiles []string
+	// Pins son los hashes SHA-256 del SubjectPublicKeyInfo aceptados en cada ambiente, en base64 y
+	// opcionalmente con el prefijo "sha256/". Si hay pins, algún certificado de la cadena verificada debe coincidir.
+	Pins map[Environment][]string
+	// Insecure desactiva la verificación de los certificados. Sólo para diagnóstico; no admite pins, porque sin
+	// verificar la cadena no hay certificados confiables contra los cuales compararlos.
+	Insecure bool
+}
+
+var (
+	tlsConfigsMu sync.RWMutex
+	tlsConfigs   = make(map[Environment]*tls.Config)
+)
+
+// SetTLSOptions define la verificación TLS de las conexiones a ARCA. Debe llamarse antes de crear los servicios;
+// sin llamarla se verifica la cadena contra las CA del sistema, sin pins.
+func SetTLSOptions(options TLSOptions) error {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	for _, fileName := range options.CAFiles {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return fmt.Errorf("SetTLSOptions: %s", err)
+		}
+		if !roots.AppendCertsFromPEM(data) {
+			return fmt.Errorf("SetTLSOptions: el archivo [%s] no contiene certificados PEM", fileName)
+		}
+	}
+
+	configs := make(map[Environment]*tls.Config, 2)
+	for _, environment := range []Environment{TESTING, PRODUCTION} {
+		pins, err := parsePins(options.Pins[environment])
+		if err != nil {
+			return fmt.Errorf("SetTLSOptions: %s", err)
+		}
+		if len(pins) > 0 && options.Insecure {
+			return errors.New("SetTLSOptions: los pins TLS no pueden combinarse con la verificación de certificados desactivada")
+		}
+		config := &tls.Config{
+			RootCAs:            roots,
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: options.Insecure,
+			ClientSessionCache: tls.NewLRUClientSessionCache(0),
+		}
+		if len(pins) > 0 {
+			config.VerifyConnection = verifyPins(pins)
+		}
+		configs[environment] = config
+	}
+
+	if options.Insecure {
+		log.Printf("ATENCIÓN: la verificación de certificados TLS de ARCA está DESACTIVADA. Los tokens y los datos " +
+			"de los comprobantes quedan expuestos a ataques de intermediario; no lo utilice en producción\n")
+	}
+
+	tlsConfigsMu.Lock()
+	tlsConfigs = configs
+	tlsConfigsMu.Unlock()
+
+	transportsMu.Lock()
+	resetTransports()
+	transportsMu.Unlock()
+	return nil
+}
+
+// arcaTLSConfig devuelve la configuración TLS para conectarse a los servidores de ARCA del ambiente. Las copias
+// comparten el caché de sesiones TLS, para retomar la sesión en las nuevas conexiones sin un handshake completo.
+func arcaTLSConfig(environment Environment) *tls.Config {
+	tlsConfigsMu.RLock()
+	defer tlsConfigsMu.RUnlock()
+	if config, exist := tlsConfigs[environment]; exist {
+		return config.Clone()
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12, ClientSessionCache: tls.NewLRUClientSessionCache(0)}
+}
+
+// parsePins decodifica los hashes SHA-256 de SubjectPublicKeyInfo.
+func parsePins(values []string) ([][]byte, error) {
+	var pins [][]byte
+	for _, value := range values {
+		value = strings.TrimPrefix(strings.TrimSpace(value), "sha256/")
+		if value == "" {
+			continue
+		}
+		pin, err := base64.StdEncoding.DecodeString(value)
+		if err != nil || len(pin) != sha256.Size {
+			return nil, fmt.Errorf("pin TLS inválido [%s]: debe ser el SHA-256 del SubjectPublicKeyInfo en base64", value)
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+// verifyPins exige que algún certificado de una cadena verificada tenga una clave pública con pin. No se usan
+// los certificados presentados por el servidor: cualquiera puede agregar a su cadena el certificado con pin.
+func verifyPins(pins [][]byte) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.VerifiedChains) == 0 {
+			return errors.New("no hay una cadena de certificados verificada contra la cual comparar los pins TLS")
+		}
+		for _, chain := range state.VerifiedChains {
+			for _, certificate := range chain {
+				sum := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+				for _, pin := range pins {
+					if bytes.Equal(sum[:], pin) {
+						return nil
+					}
+				}
+			}
+		}
+		sum := sha256.Sum256(state.PeerCertificates[0].RawSubjectPublicKeyInfo)
+		return fmt.Errorf("el certificado del servidor (sha256/%s) no coincide con los pins TLS configurados",
+			base64.StdEncoding.EncodeToString(sum[:]))
+	}
+}
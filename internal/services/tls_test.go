@@ -0,0 +1,130 @@
+package services
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCertificate es un certificado de prueba con su clave.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// newTestCertificate emite un certificado firmado por parent; si parent es nil es una CA autofirmada.
+func newTestCertificate(t *testing.T, name string, parent *testCertificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	issuer, signer := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		issuer, signer = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{certificate: certificate, key: key}
+}
+
+func (c *testCertificate) pin() string {
+	sum := sha256.Sum256(c.certificate.RawSubjectPublicKeyInfo)
+	return "sha256/" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// TestTLSPins verifica que los pins se comparan con la cadena verificada y no con los certificados que envía
+// el servidor, que pueden incluir el certificado con pin sin haber sido emitidos por él.
+func TestTLSPins(t *testing.T) {
+	arcaCA := newTestCertificate(t, "CA de ARCA", nil)
+	otherCA := newTestCertificate(t, "Otra CA confiable", nil)
+	arcaLeaf := newTestCertificate(t, "ARCA", arcaCA)
+	otherLeaf := newTestCertificate(t, "Atacante", otherCA)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	var bundle []byte
+	for _, ca := range []*testCertificate{arcaCA, otherCA} {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.certificate.Raw})...)
+	}
+	if err := os.WriteFile(caFile, bundle, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetTLSOptions(TLSOptions{}) })
+
+	for _, c := range []struct {
+		name  string
+		chain []*testCertificate
+		pin   *testCertificate
+		ok    bool
+	}{
+		{"cadena de ARCA con pin de la CA", []*testCertificate{arcaLeaf, arcaCA}, arcaCA, true},
+		{"cadena de ARCA con pin del certificado", []*testCertificate{arcaLeaf}, arcaLeaf, true},
+		{"otra cadena confiable sin pin", []*testCertificate{otherLeaf, otherCA}, arcaCA, false},
+		{"otra cadena con el certificado con pin agregado", []*testCertificate{otherLeaf, otherCA, arcaCA}, arcaCA, false},
+		{"otra cadena con el certificado de ARCA agregado", []*testCertificate{otherLeaf, arcaLeaf}, arcaLeaf, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			certificate := tls.Certificate{PrivateKey: c.chain[0].key}
+			for _, link := range c.chain {
+				certificate.Certificate = append(certificate.Certificate, link.certificate.Raw)
+			}
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, "OK")
+			}))
+			server.TLS = &tls.Config{Certificates: []tls.Certificate{certificate}}
+			server.Config.ErrorLog = log.New(io.Discard, "", 0)
+			server.StartTLS()
+			defer server.Close()
+
+			if err := SetTLSOptions(TLSOptions{CAFiles: []string{caFile}, Pins: map[Environment][]string{TESTING: {c.pin.pin()}}}); err != nil {
+				t.Fatal(err)
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: arcaTLSConfig(TESTING)}}
+			response, err := client.Get(server.URL)
+			if err == nil {
+				response.Body.Close()
+			}
+			if (err == nil) != c.ok {
+				t.Errorf("conexión = %v, se esperaba aceptada %v", err, c.ok)
+			}
+		})
+	}
+
+	if err := SetTLSOptions(TLSOptions{Pins: map[Environment][]string{PRODUCTION: {arcaCA.pin()}}, Insecure: true}); err == nil {
+		t.Error("SetTLSOptions() aceptó pins con la verificación de certificados desactivada")
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/hooklift/gowsdl/soap"
+	"github.com/sehogas/goarca/ws/wsfe"
+)
+
+// BenchmarkFEDummy compara un cliente SOAP creado en cada llamada, como se hacía antes, con el cliente de larga
+// vida de Wsfe, que reutiliza las conexiones y las sesiones TLS.
+func BenchmarkFEDummy(b *testing.B) {
+	server := newFakeArcaTLS(b).server
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	b.Run("PerCallClient", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			client := soap.NewClient(server.URL, soap.WithTLS(&tls.Config{RootCAs: roots}))
+			if _, err := wsfe.NewServiceSoap(client).FEDummyContext(context.Background(), &wsfe.FEDummy{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("PooledClient", func(b *testing.B) {
+		ws, err := NewWsfe(slog.New(slog.NewTextHandler(io.Discard, nil)), TESTING, &Tenant{Cuit: 20123456786}, false, false)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := ws.FEDummy(context.Background()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("PooledClientParallel", func(b *testing.B) {
+		ws, err := NewWsfe(slog.New(slog.NewTextHandler(io.Discard, nil)), TESTING, &Tenant{Cuit: 20123456786}, false, false)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := ws.FEDummy(context.Background()); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
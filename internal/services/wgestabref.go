@@ -1,118 +1,90 @@
 package services
 
 import (
-	"crypto/tls"
-	"encoding/xml"
+	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
-	"reflect"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/hooklift/gowsdl/soap"
 	"github.com/sehogas/goarca/ws/wgestabref"
 )
 
 type Wsgestabref struct {
-	logger        *slog.Logger
-	serviceName   string
-	environment   Environment
-	url           string
-	cuit          int64
-	tipoAgente    string
-	rol           string
-	soapTlsConfig tls.Config
-	printXML      bool
-	saveXML       bool
+	logger      *slog.Logger
+	serviceName string
+	environment Environment
+	url         string
+	tenant      *Tenant
+	cuit        int64
+	tipoAgente  string
+	rol         string
+	httpClient  *http.Client
+	service     wgestabref.WgesTabRefSoap
 }
 
-func Newgestabref(logger *slog.Logger, environment Environment, cuit int64, printXML, saveXML bool) (*Wsgestabref, error) {
+func Newgestabref(logger *slog.Logger, environment Environment, tenant *Tenant, printXML, saveXML bool) (*Wsgestabref, error) {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	}
 
-	var url string
-	if environment == PRODUCTION {
-		url = URLWGESTABREFProduction
-	} else {
-		url = URLWGESTABREFTesting
-	}
+	url := ServiceURL(environment, EndpointWGESTABREF)
 
-	if len(os.Getenv("WGESTABREF_TIPO_AGENTE")) != 4 {
-		return nil, fmt.Errorf("missing environment variable WGESTABREF_TIPO_AGENTE")
+	if tenant.Wgestabref == nil {
+		return nil, fmt.Errorf("CUIT %d: missing wgestabref tipoAgente/rol", tenant.Cuit)
 	}
 
-	if len(os.Getenv("WGESTABREF_ROL")) != 4 {
-		return nil, fmt.Errorf("missing or invalid environment variable WGESTABREF_ROL")
+	if err := tenant.Wgestabref.validate("wgestabref"); err != nil {
+		return nil, fmt.Errorf("CUIT %d: %s", tenant.Cuit, err)
 	}
 
-	return &Wsgestabref{
+	ws := &Wsgestabref{
 		logger:      logger,
 		serviceName: "wGesTabRef",
 		environment: environment,
 		url:         url,
-		cuit:        cuit,
-		tipoAgente:  os.Getenv("WGESTABREF_TIPO_AGENTE"),
-		rol:         os.Getenv("WGESTABREF_ROL"),
-		soapTlsConfig: tls.Config{
-			InsecureSkipVerify: true,
-		},
-		printXML: printXML,
-		saveXML:  saveXML,
-	}, nil
+		tenant:      tenant,
+		cuit:        tenant.Cuit,
+		tipoAgente:  tenant.Wgestabref.TipoAgente,
+		rol:         tenant.Wgestabref.Rol,
+	}
+	ws.httpClient = newSOAPHTTPClient(ws.serviceName, ws.cuit, url, environment, func(ctx context.Context, client *http.Client) error {
+		probe := &Wsgestabref{serviceName: ws.serviceName, service: wgestabref.NewWgesTabRefSoap(soap.NewClient(url, soap.WithHTTPClient(client)))}
+		_, err := probe.Dummy(ctx)
+		return err
+	}, newExchangeCapture(logger, printXML, saveXML))
+	ws.service = wgestabref.NewWgesTabRefSoap(soap.NewClient(url, soap.WithHTTPClient(ws.httpClient)))
+	return ws, nil
 }
 
-func (ws *Wsgestabref) PrintAndSaveXML(obj interface{}) {
-	if ws.printXML || ws.saveXML {
-		data, err := xml.MarshalIndent(obj, " ", "  ")
-		if err == nil {
-			if ws.printXML {
-				ws.logger.Info("printXML", "XML", string(data))
-			}
-			if ws.saveXML {
-				path := fmt.Sprintf("xml/%s", ws.serviceName)
-				if _, err := os.Stat(path); os.IsNotExist(err) {
-					if err := os.Mkdir(path, os.ModePerm); err != nil {
-						ws.logger.Error(fmt.Sprintf("Error creating directory [ %s ]", path), "err", err.Error())
-						return
-					}
-				}
-				fileName := fmt.Sprintf("%s/%s_%s.xml", path, strings.ReplaceAll(strings.ReplaceAll(reflect.TypeOf(obj).String(), "*", ""), " ", "_"), strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(time.Now().Format(time.RFC3339), ":", ""), "-", ""), "T", ""))
-				f, err := os.Create(fileName)
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error creating file [ %s ]", fileName), "err", err.Error())
-				}
-				defer f.Close()
-				_, err = f.WriteString(string(data))
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error writing file [ %s ]", fileName), "err", err.Error())
-				}
-				ws.logger.Info("saveXML", "file", fileName)
-			}
-		}
-	}
+// ServiceName devuelve el nombre del servicio ante WSAA.
+func (ws *Wsgestabref) ServiceName() string {
+	return ws.serviceName
 }
 
-func (ws *Wsgestabref) Dummy() (*wgestabref.WsDummyResponse, error) {
-	request := &wgestabref.Dummy{}
+func (ws *Wsgestabref) Dummy(ctx context.Context) (*wgestabref.WsDummyResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "Dummy")
+	defer cancel()
 
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
+	request := &wgestabref.Dummy{}
 
-	response, err := service.Dummy(request)
+	response, err := ws.service.DummyContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	return response.DummyResult, nil
+	return response.DummyResult, businessError(ctx, response.DummyResult)
 }
 
-func (ws *Wsgestabref) ConsultarFechaUltAct(idReferencia string) (*wgestabref.FechaUltAct, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ConsultarFechaUltAct(ctx context.Context, idReferencia string) (*wgestabref.FechaUltAct, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ConsultarFechaUltAct")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ConsultarFechaUltAct{
@@ -126,25 +98,21 @@ func (ws *Wsgestabref) ConsultarFechaUltAct(idReferencia string) (*wgestabref.Fe
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ConsultarFechaUltAct(request)
+	response, err := ws.service.ConsultarFechaUltActContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ConsultarFechaUltActResult, nil
+	return response.ConsultarFechaUltActResult, businessError(ctx, response.ConsultarFechaUltActResult)
 }
 
-func (ws *Wsgestabref) ListaArancel(idReferencia string) (*wgestabref.Opciones, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaArancel(ctx context.Context, idReferencia string) (*wgestabref.Opciones, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaArancel")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaArancel{
@@ -158,25 +126,21 @@ func (ws *Wsgestabref) ListaArancel(idReferencia string) (*wgestabref.Opciones,
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaArancel(request)
+	response, err := ws.service.ListaArancelContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaArancelResult, nil
+	return response.ListaArancelResult, businessError(ctx, response.ListaArancelResult)
 }
 
-func (ws *Wsgestabref) ListaDescripcion(idReferencia string) (*wgestabref.Descripciones, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaDescripcion(ctx context.Context, idReferencia string) (*wgestabref.Descripciones, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaDescripcion")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaDescripcion{
@@ -190,25 +154,21 @@ func (ws *Wsgestabref) ListaDescripcion(idReferencia string) (*wgestabref.Descri
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaDescripcion(request)
+	response, err := ws.service.ListaDescripcionContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaDescripcionResult, nil
+	return response.ListaDescripcionResult, businessError(ctx, response.ListaDescripcionResult)
 }
 
-func (ws *Wsgestabref) ListaDescripcionDecodificacion(idReferencia string) (*wgestabref.DescripcionesCodificaciones, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaDescripcionDecodificacion(ctx context.Context, idReferencia string) (*wgestabref.DescripcionesCodificaciones, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaDescripcionDecodificacion")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaDescripcionDecodificacion{
@@ -222,25 +182,21 @@ func (ws *Wsgestabref) ListaDescripcionDecodificacion(idReferencia string) (*wge
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaDescripcionDecodificacion(request)
+	response, err := ws.service.ListaDescripcionDecodificacionContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaDescripcionDecodificacionResult, nil
+	return response.ListaDescripcionDecodificacionResult, businessError(ctx, response.ListaDescripcionDecodificacionResult)
 }
 
-func (ws *Wsgestabref) ListaEmpresas(idReferencia string) (*wgestabref.Empresas, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaEmpresas(ctx context.Context, idReferencia string) (*wgestabref.Empresas, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaEmpresas")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaEmpresas{
@@ -254,25 +210,21 @@ func (ws *Wsgestabref) ListaEmpresas(idReferencia string) (*wgestabref.Empresas,
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaEmpresas(request)
+	response, err := ws.service.ListaEmpresasContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaEmpresasResult, nil
+	return response.ListaEmpresasResult, businessError(ctx, response.ListaEmpresasResult)
 }
 
-func (ws *Wsgestabref) ListaLugaresOperativos(idReferencia string) (*wgestabref.LugaresOperativos, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaLugaresOperativos(ctx context.Context, idReferencia string) (*wgestabref.LugaresOperativos, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaLugaresOperativos")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaLugaresOperativos{
@@ -286,25 +238,21 @@ func (ws *Wsgestabref) ListaLugaresOperativos(idReferencia string) (*wgestabref.
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaLugaresOperativos(request)
+	response, err := ws.service.ListaLugaresOperativosContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaLugaresOperativosResult, nil
+	return response.ListaLugaresOperativosResult, businessError(ctx, response.ListaLugaresOperativosResult)
 }
 
-func (ws *Wsgestabref) ListaPaisesAduanas(idReferencia string) (*wgestabref.PaisesAduanas, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaPaisesAduanas(ctx context.Context, idReferencia string) (*wgestabref.PaisesAduanas, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaPaisesAduanas")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaPaisesAduanas{
@@ -318,25 +266,21 @@ func (ws *Wsgestabref) ListaPaisesAduanas(idReferencia string) (*wgestabref.Pais
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaPaisesAduanas(request)
+	response, err := ws.service.ListaPaisesAduanasContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaPaisesAduanasResult, nil
+	return response.ListaPaisesAduanasResult, businessError(ctx, response.ListaPaisesAduanasResult)
 }
 
-func (ws *Wsgestabref) ListaTablasReferencia() (*wgestabref.TablasReferencia, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaTablasReferencia(ctx context.Context) (*wgestabref.TablasReferencia, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaTablasReferencia")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaTablasReferencia{
@@ -349,25 +293,21 @@ func (ws *Wsgestabref) ListaTablasReferencia() (*wgestabref.TablasReferencia, er
 		},
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaTablasReferencia(request)
+	response, err := ws.service.ListaTablasReferenciaContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaTablasReferenciaResult, nil
+	return response.ListaTablasReferenciaResult, businessError(ctx, response.ListaTablasReferenciaResult)
 }
 
-func (ws *Wsgestabref) ListaVigencias(idReferencia string) (*wgestabref.Vigencias, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaVigencias(ctx context.Context, idReferencia string) (*wgestabref.Vigencias, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaVigencias")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaVigencias{
@@ -381,25 +321,21 @@ func (ws *Wsgestabref) ListaVigencias(idReferencia string) (*wgestabref.Vigencia
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaVigencias(request)
+	response, err := ws.service.ListaVigenciasContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaVigenciasResult, nil
+	return response.ListaVigenciasResult, businessError(ctx, response.ListaVigenciasResult)
 }
 
-func (ws *Wsgestabref) ListaDatoComplementario(idReferencia string) (*wgestabref.DatosComplementarios, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsgestabref) ListaDatoComplementario(ctx context.Context, idReferencia string) (*wgestabref.DatosComplementarios, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ListaDatoComplementario")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wgestabref.ListaDatoComplementario{
@@ -413,17 +349,10 @@ func (ws *Wsgestabref) ListaDatoComplementario(idReferencia string) (*wgestabref
 		IdReferencia: idReferencia,
 	}
 
-	//ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wgestabref.NewWgesTabRefSoap(client)
-
-	response, err := service.ListaDatoComplementario(request)
+	response, err := ws.service.ListaDatoComplementarioContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ListaDatoComplementarioResult, nil
+	return response.ListaDatoComplementarioResult, businessError(ctx, response.ListaDatoComplementarioResult)
 }
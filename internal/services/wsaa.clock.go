@@ -0,0 +1,157 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Diferencia de reloj a partir de la cual se advierte en el log.
+const clockSkewWarning = time.Minute
+
+// lastUniqueID se inicializa con la hora para que los uniqueId no se repitan entre reinicios.
+var lastUniqueID = uint32(time.Now().Unix())
+
+// nextUniqueID devuelve un uniqueId distinto para cada loginTicketRequest.
+func nextUniqueID() uint32 {
+	return atomic.AddUint32(&lastUniqueID, 1)
+}
+
+// serverClock estima la diferencia entre el reloj local y el de un servidor de ARCA a partir
+// del header Date de sus respuestas.
+type serverClock struct {
+	host string
+
+	mu     sync.Mutex
+	offset time.Duration
+}
+
+var (
+	serverClocksMu sync.Mutex
+	serverClocks   = make(map[string]*serverClock)
+)
+
+// clockFor devuelve el reloj estimado del servidor de la URL, compartido por todas las conexiones al mismo host.
+func clockFor(rawURL string) *serverClock {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Host
+	}
+
+	serverClocksMu.Lock()
+	defer serverClocksMu.Unlock()
+	clock, exist := serverClocks[host]
+	if !exist {
+		clock = &serverClock{host: host}
+		serverClocks[host] = clock
+	}
+	return clock
+}
+
+// Now devuelve la hora estimada del servidor.
+func (c *serverClock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// Offset devuelve cuánto adelanta el reloj del servidor respecto del local (negativo si atrasa).
+func (c *serverClock) Offset() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offset
+}
+
+// observe registra la hora informada por el servidor en una respuesta recibida entre sent y received.
+func (c *serverClock) observe(date string, sent, received time.Time) {
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return
+	}
+	// Date tiene resolución de segundos (truncada) y se compara con el punto medio del viaje.
+	offset := serverTime.Add(500 * time.Millisecond).Sub(sent.Add(received.Sub(sent) / 2)).Round(time.Second)
+
+	c.mu.Lock()
+	previous := c.offset
+	c.offset = offset
+	c.mu.Unlock()
+
+	if offset.Abs() >= clockSkewWarning && (offset-previous).Abs() >= time.Second {
+		log.Printf("El reloj local difiere %s del de %s; se corrigen las fechas del loginTicketRequest. Sincronice el reloj del servidor (NTP)\n", offset, c.host)
+	}
+}
+
+// clockTransport registra la hora de las respuestas del servidor en el reloj estimado.
+type clockTransport struct {
+	base  http.RoundTripper
+	clock *serverClock
+}
+
+func (t *clockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sent := time.Now()
+	res, err := t.base.RoundTrip(req)
+	if err == nil {
+		t.clock.observe(res.Header.Get("Date"), sent, time.Now())
+	}
+	return res, err
+}
+
+// newClockHTTPClient devuelve un cliente HTTP con los mismos tiempos de espera que el cliente SOAP por
+// defecto, sobre el transporte compartido del ambiente, que además registra la hora del servidor y captura
+// cada intercambio con WSAA del CUIT.
+func newClockHTTPClient(clock *serverClock, environment Environment, cuit int64, capture CaptureHook) *http.Client {
+	return &http.Client{
+		Timeout: 90 * time.Second,
+		Transport: &clockTransport{
+			base: &captureTransport{
+				serviceName: "wsaa",
+				cuit:        cuit,
+				operation:   "loginCms",
+				capture:     capture,
+				base:        &keepAliveTransport{base: arcaTransport(environment)},
+			},
+			clock: clock,
+		},
+	}
+}
+
+// ClockSkewError indica que WSAA rechazó el loginTicketRequest por sus fechas y que la causa
+// más probable es la diferencia entre el reloj local y el de WSAA.
+type ClockSkewError struct {
+	Offset     time.Duration
+	LocalTime  time.Time
+	ServerTime time.Time
+	Err        error
+}
+
+func (e *ClockSkewError) Error() string {
+	direction := "atrasado"
+	if e.Offset < 0 {
+		direction = "adelantado"
+	}
+	return fmt.Sprintf("GetLoginTicket: WSAA rechazó las fechas del loginTicketRequest; el reloj local está %s %s respecto de WSAA "+
+		"(hora local %s, hora WSAA %s). Sincronice el reloj del servidor (NTP): %s",
+		direction, e.Offset.Abs(), e.LocalTime.Format(time.RFC3339), e.ServerTime.Format(time.RFC3339), e.Err)
+}
+
+func (e *ClockSkewError) Unwrap() error {
+	return e.Err
+}
+
+// isClockFault indica si WSAA rechazó el login por las fechas de generación o expiración.
+func isClockFault(err error) bool {
+	var wsaaErr *WsaaError
+	if !errors.As(err, &wsaaErr) {
+		return false
+	}
+	switch wsaaErr.Code {
+	case WsaaGenerationTimeInvalid, WsaaExpirationTimeInvalid, WsaaExpirationTimeExpired:
+		return true
+	}
+	message := strings.ToLower(wsaaErr.Message)
+	return strings.Contains(message, "generationtime") || strings.Contains(message, "expirationtime")
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hooklift/gowsdl/soap"
+)
+
+// Códigos de falla SOAP devueltos por WSAA.
+const (
+	WsaaAlreadyAuthenticated     = "coe.alreadyAuthenticated"
+	WsaaNotAuthorized            = "coe.notAuthorized"
+	WsaaCmsBad                   = "cms.bad"
+	WsaaCmsCertExpired           = "cms.cert.expired"
+	WsaaCmsCertInvalid           = "cms.cert.invalid"
+	WsaaCmsCertUntrusted         = "cms.cert.untrusted"
+	WsaaCmsSignInvalid           = "cms.sign.invalid"
+	WsaaGenerationTimeInvalid    = "xml.generationTime.invalid"
+	WsaaExpirationTimeInvalid    = "xml.expirationTime.invalid"
+	WsaaExpirationTimeExpired    = "xml.expirationTime.expired"
+	WsaaServiceNotFound          = "wsn.notFound"
+	WsaaServiceUnavailable       = "wsn.unavailable"
+	WsaaUnavailable              = "wsaa.unavailable"
+	WsaaInternalError            = "wsaa.internalError"
+	wsaaAlreadyAuthenticatedWait = 10 * time.Minute
+)
+
+var ErrAlreadyAuthenticated = errors.New("WSAA ya emitió un ticket de acceso vigente para el servicio")
+
+// WsaaError es una falla SOAP devuelta por WSAA, con su código sin prefijo de espacio de nombres.
+type WsaaError struct {
+	Code    string
+	Message string
+}
+
+func (e *WsaaError) Error() string {
+	return fmt.Sprintf("GetLoginTicket: %s (%s)", e.Message, e.Code)
+}
+
+// Is permite comparar la falla con los errores centinela mediante errors.Is.
+func (e *WsaaError) Is(target error) bool {
+	return target == ErrAlreadyAuthenticated && e.Code == WsaaAlreadyAuthenticated
+}
+
+// TicketUnavailableError indica que no hay ticket de acceso disponible para el servicio
+// y que no tiene sentido volver a intentarlo antes de RetryAfter.
+type TicketUnavailableError struct {
+	ServiceName string
+	Cuit        int64
+	RetryAfter  time.Duration
+	Err         error
+}
+
+func (e *TicketUnavailableError) Error() string {
+	return fmt.Sprintf("ticket de acceso no disponible para [%s] CUIT [%d], reintente en %s: %s",
+		e.ServiceName, e.Cuit, e.RetryAfter.Round(time.Second), e.Err)
+}
+
+func (e *TicketUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// wsaaFault es la representación mínima de una falla SOAP, independiente del prefijo de espacio de nombres.
+type wsaaFault struct {
+	Code   string `xml:"Body>Fault>faultcode"`
+	String string `xml:"Body>Fault>faultstring"`
+}
+
+// parseWsaaError convierte el error devuelto por el cliente SOAP en un *WsaaError cuando corresponde a una falla SOAP,
+// o en un *TransportError si no se obtuvo respuesta de WSAA o respondió con un error HTTP sin falla SOAP.
+func parseWsaaError(err error) error {
+	var code, message string
+
+	var httpErr *soap.HTTPError
+	var soapFault *soap.SOAPFault
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &httpErr):
+		fault := wsaaFault{}
+		if xml.Unmarshal(httpErr.ResponseBody, &fault) != nil || fault.Code == "" {
+			return &TransportError{ServiceName: "wsaa", Operation: "loginCms", StatusCode: httpErr.StatusCode, Err: err}
+		}
+		code, message = fault.Code, fault.String
+	case errors.As(err, &soapFault):
+		code, message = soapFault.Code, soapFault.String
+	case errors.As(err, &urlErr):
+		return &TransportError{ServiceName: "wsaa", Operation: "loginCms", Err: err}
+	default:
+		return fmt.Errorf("GetLoginTicket: %w", err)
+	}
+
+	if i := strings.LastIndex(code, ":"); i >= 0 {
+		code = code[i+1:]
+	}
+	return &WsaaError{Code: strings.TrimSpace(code), Message: strings.TrimSpace(message)}
+}
@@ -1,13 +1,14 @@
 package services
 
 import (
-	"crypto/rsa"
-	"crypto/tls"
+	"crypto"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/xml"
 	"fmt"
+	"log/slog"
 	"os"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/hooklift/gowsdl/soap"
@@ -16,23 +17,28 @@ import (
 )
 
 type Wsaa struct {
-	environment     Environment
-	urlWsaa         string
-	tickets         map[string]*LoginTicketResponse
-	privateKeyFile  string
-	certificateFile string
-	cuit            int64
-	soapTlsConfig   tls.Config
+	environment Environment
+	urlWsaa     string
+	tenant      *Tenant
+	cuit        int64
+	clock       *serverClock
+	service     wsaa.LoginCMS
 }
 
 type LoginTicket struct {
 	ServiceName    string
 	Token          string
 	Sign           string
+	GenerationTime time.Time
 	ExpirationTime time.Time
 	Cuit           int64
 }
 
+// validFor indica si el ticket sigue vigente durante al menos d.
+func (t *LoginTicket) validFor(d time.Duration) bool {
+	return t.ExpirationTime.After(time.Now().Add(d))
+}
+
 // HeaderLoginTicket es la cabecera de la estructura de request y response
 type HeaderLoginTicket struct {
 	Source         string `xml:"source,omitempty"`
@@ -63,131 +69,144 @@ type LoginTicketResponse struct {
 	Credentials *Credentials       `xml:"credentials,omitempty"`
 }
 
-func NewWsaa(environment Environment, privateKeyFile string, certificateFile string, cuit int64) (*Wsaa, error) {
-	var url string
-	if environment == PRODUCTION {
-		url = URLWSAAProduction
-	} else {
-		url = URLWSAATesting
-	}
+var (
+	wsaaCaptureMu sync.RWMutex
+	wsaaCapture   CaptureHook
+)
 
-	if _, err := os.Stat(privateKeyFile); err != nil {
-		return nil, fmt.Errorf("privateKeyFile: %s", err)
+// SetWsaaCapture define el registro de los intercambios con WSAA, con el mismo criterio que los servicios:
+// con printXML se registran en el log y con saveXML se guardan en el destino de auditoría, con las
+// credenciales del ticket ocultas. Los hooks de AddCaptureHook los reciben siempre.
+func SetWsaaCapture(logger *slog.Logger, printXML, saveXML bool) {
+	capture := newExchangeCapture(logger, printXML, saveXML)
+	wsaaCaptureMu.Lock()
+	defer wsaaCaptureMu.Unlock()
+	wsaaCapture = capture
+}
+
+func currentWsaaCapture() CaptureHook {
+	wsaaCaptureMu.RLock()
+	defer wsaaCaptureMu.RUnlock()
+	return wsaaCapture
+}
+
+func NewWsaa(environment Environment, tenant *Tenant) (*Wsaa, error) {
+	url := ServiceURL(environment, EndpointWSAA)
+
+	if tenant.usesKeyFile() {
+		if _, err := os.Stat(tenant.PrivateKeyFile); err != nil {
+			return nil, fmt.Errorf("privateKeyFile: %s", err)
+		}
 	}
-	if _, err := os.Stat(certificateFile); err != nil {
-		return nil, fmt.Errorf("certificateFile: %s", err)
+	if tenant.CertificateFile != "" {
+		if _, err := os.Stat(tenant.CertificateFile); err != nil {
+			return nil, fmt.Errorf("certificateFile: %s", err)
+		}
 	}
 	return &Wsaa{
-		environment:     environment,
-		urlWsaa:         url,
-		tickets:         make(map[string]*LoginTicketResponse),
-		privateKeyFile:  privateKeyFile,
-		certificateFile: certificateFile,
-		cuit:            cuit,
-		soapTlsConfig: tls.Config{
-			InsecureSkipVerify: true,
-		},
+		environment: environment,
+		urlWsaa:     url,
+		tenant:      tenant,
+		cuit:        tenant.Cuit,
+		clock:       clockFor(url),
+		service:     wsaa.NewLoginCMS(soap.NewClient(url, soap.WithHTTPClient(newClockHTTPClient(clockFor(url), environment, tenant.Cuit, currentWsaaCapture())))),
 	}, nil
 }
 
-// GetLoginTicket devuelve el ticket de acceso afip correspondiente al servicio pasado por parámetro.
+// GetLoginTicket solicita a WSAA un nuevo ticket de acceso afip para el servicio pasado por parámetro.
+// No conserva el ticket: la reutilización entre llamadas la resuelve TicketManager, porque WSAA no emite
+// un nuevo ticket para el mismo servicio hasta que venza el actual.
 func (c *Wsaa) GetLoginTicket(serviceName string) (*LoginTicket, error) {
-	var renovar bool = true
+	privateKey, certificate, err := c.tenant.KeyPair()
+	if err != nil {
+		return nil, fmt.Errorf("GetLoginTicket: %w", err)
+	}
 
-	ticket := c.tickets[serviceName]
-	if ticket != nil {
-		expTime, err := time.Parse(time.RFC3339, ticket.Header.ExpirationTime)
-		if err != nil {
-			return nil, fmt.Errorf("GetLoginTicket: Error leyendo fecha de expiración del ticket. %s", err)
+	localTime, offset := time.Now(), c.clock.Offset()
+	response, err := c.loginCms(serviceName, certificate, privateKey)
+	if isClockFault(err) && c.clock.Offset() != offset {
+		// La respuesta de WSAA trajo su hora: reintento una vez con las fechas corregidas.
+		localTime, offset = time.Now(), c.clock.Offset()
+		response, err = c.loginCms(serviceName, certificate, privateKey)
+	}
+	if isClockFault(err) {
+		return nil, &ClockSkewError{
+			Offset:     c.clock.Offset(),
+			LocalTime:  localTime,
+			ServerTime: localTime.Add(c.clock.Offset()),
+			Err:        err,
 		}
-		renovar = time.Now().After(expTime)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	if renovar {
-		expiration := time.Now().Add(10 * time.Minute)
-		generationTime := time.Now().Add(-10 * time.Minute).Format(time.RFC3339)
-		expirationTime := expiration.Format(time.RFC3339)
+	expirationTime, err := time.Parse(time.RFC3339, response.Header.ExpirationTime)
+	if err != nil {
+		return nil, fmt.Errorf("GetLoginTicket: Error leyendo fecha de expiración del ticket. %s", err)
+	}
 
-		privateKey, err := util.ReadPrivateKey(c.privateKeyFile)
-		if err != nil {
-			return nil, fmt.Errorf("GetLoginTicket: Error leyendo clave privada: %s", err)
-		}
+	// La fecha de generación es informativa; si no se puede leer, queda en cero.
+	generationTime, _ := time.Parse(time.RFC3339, response.Header.GenerationTime)
 
-		certificate, err := util.ReadCertificate(c.certificateFile)
-		if err != nil {
-			return nil, fmt.Errorf("GetLoginTicket: Error leyendo certificado: %s", err)
-		}
+	loginTicket := &LoginTicket{
+		ServiceName:    serviceName,
+		Token:          response.Credentials.Token,
+		Sign:           response.Credentials.Sign,
+		GenerationTime: generationTime,
+		ExpirationTime: expirationTime,
+		Cuit:           c.cuit,
+	}
 
-		// Armo estructura request
-		loginTicketRequest := LoginTicketRequest{
-			Version: "1.0",
-			Header: &HeaderLoginTicket{
-				UniqueID:       1,
-				GenerationTime: generationTime,
-				ExpirationTime: expirationTime,
-			},
-			Service: serviceName,
-		}
+	return loginTicket, nil
+}
 
-		loginTicketRequestXML, err := xml.MarshalIndent(loginTicketRequest, " ", "  ")
-		if err != nil {
-			return nil, fmt.Errorf("GetLoginTicket: Error armando request XML. %s", err)
-		}
-		content := []byte(string(loginTicketRequestXML))
+// loginCms firma un loginTicketRequest para el servicio y lo envía a WSAA. Las fechas de generación
+// y expiración se calculan con la hora estimada de WSAA para tolerar diferencias de reloj.
+func (c *Wsaa) loginCms(serviceName string, certificate *x509.Certificate, privateKey crypto.Signer) (*LoginTicketResponse, error) {
+	now := c.clock.Now()
+
+	// Armo estructura request
+	loginTicketRequest := LoginTicketRequest{
+		Version: "1.0",
+		Header: &HeaderLoginTicket{
+			UniqueID:       nextUniqueID(),
+			GenerationTime: now.Add(-10 * time.Minute).Format(time.RFC3339),
+			ExpirationTime: now.Add(10 * time.Minute).Format(time.RFC3339),
+		},
+		Service: serviceName,
+	}
 
-		// Creo CMS (Cryptographic Message Syntax)
-		cms, err := util.EncodeCMS(content, certificate, privateKey.(*rsa.PrivateKey))
-		if err != nil {
-			return nil, fmt.Errorf("GetLoginTicket: Error creando CMS: %s", err)
-		}
+	loginTicketRequestXML, err := xml.MarshalIndent(loginTicketRequest, " ", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("GetLoginTicket: Error armando request XML. %s", err)
+	}
 
-		// Convierto CMS a base64
-		cmsBase64 := base64.StdEncoding.EncodeToString(cms)
-
-		// Armo conexión SOAP y solicitud
-		conexion := soap.NewClient(c.urlWsaa)
-		service := wsaa.NewLoginCMS(conexion)
-
-		request := wsaa.LoginCms{In0: cmsBase64}
-
-		// Llamo al servicio de autenticación afip wssa
-		responseXML, err := service.LoginCms(&request)
-		if err != nil {
-			response := soap.SOAPEnvelopeResponse{
-				Body: soap.SOAPBodyResponse{
-					Content: &soap.SOAPFault{},
-					Fault:   &soap.SOAPFault{},
-				},
-			}
-			if err := xml.Unmarshal([]byte(err.Error()[strings.Index(err.Error(), "<soapenv:Envelope"):]), &response); err != nil {
-				return nil, fmt.Errorf("GetLoginTicket: Error desarmando respuesta XML. %s", err)
-			}
-			return nil, fmt.Errorf("GetLoginTicket: %s", response.Body.Fault.String)
-		}
+	// Creo CMS (Cryptographic Message Syntax)
+	cms, err := util.EncodeCMS(loginTicketRequestXML, certificate, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("GetLoginTicket: Error creando CMS: %s", err)
+	}
 
-		// Desarmo respuesta XML
-		response := LoginTicketResponse{}
-		if err := xml.Unmarshal([]byte(responseXML.LoginCmsReturn), &response); err != nil {
-			return nil, fmt.Errorf("GetLoginTicket: Error desarmando respuesta XML. %s", err)
-		}
+	// Convierto CMS a base64
+	cmsBase64 := base64.StdEncoding.EncodeToString(cms)
 
-		// Almaceno ticket de respuesta (porque no se puede llamar nuevamente al servicio hasta dentro de 10 minutos,
-		// hay que seguir usando el ticket actual. El vencimiento de los ticket de afip suele ser de 12 horas)
-		c.tickets[serviceName] = &response
-	}
+	// Armo la solicitud
+	request := wsaa.LoginCms{In0: cmsBase64}
 
-	expirationTime, err := time.Parse(time.RFC3339, c.tickets[serviceName].Header.ExpirationTime)
+	// Llamo al servicio de autenticación afip wssa
+	responseXML, err := c.service.LoginCms(&request)
 	if err != nil {
-		return nil, fmt.Errorf("GetLoginTicket: Error leyendo fecha de expiración del ticket. %s", err)
+		return nil, parseWsaaError(err)
 	}
 
-	loginTicket := &LoginTicket{
-		ServiceName:    serviceName,
-		Token:          c.tickets[serviceName].Credentials.Token,
-		Sign:           c.tickets[serviceName].Credentials.Sign,
-		ExpirationTime: expirationTime,
-		Cuit:           c.cuit,
+	// Desarmo respuesta XML
+	response := LoginTicketResponse{}
+	if err := xml.Unmarshal([]byte(responseXML.LoginCmsReturn), &response); err != nil {
+		return nil, fmt.Errorf("GetLoginTicket: Error desarmando respuesta XML. %s", err)
 	}
-
-	return loginTicket, nil
+	if response.Header == nil || response.Credentials == nil {
+		return nil, fmt.Errorf("GetLoginTicket: respuesta de WSAA sin header o credentials")
+	}
+	return &response, nil
 }
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+// TestWsaaCapture verifica que el login a WSAA pasa por la captura de intercambios y que las credenciales
+// del ticket quedan ocultas en lo que se registra.
+func TestWsaaCapture(t *testing.T) {
+	newFakeArca(t)
+	tenant := testTenant(t, 20123456786)
+
+	var mu sync.Mutex
+	var exchanges []*Exchange
+	wsaaCaptureMu.Lock()
+	previous := wsaaCapture
+	wsaaCapture = func(exchange *Exchange) {
+		mu.Lock()
+		defer mu.Unlock()
+		exchanges = append(exchanges, exchange)
+	}
+	wsaaCaptureMu.Unlock()
+	t.Cleanup(func() {
+		wsaaCaptureMu.Lock()
+		wsaaCapture = previous
+		wsaaCaptureMu.Unlock()
+	})
+
+	ticket, err := GenerarTA(TESTING, "wsfe", tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(exchanges) != 1 {
+		t.Fatalf("intercambios capturados = %d, se esperaba 1", len(exchanges))
+	}
+	exchange := exchanges[0]
+	if exchange.ServiceName != "wsaa" || exchange.Operation != "loginCms" || exchange.Cuit != tenant.Cuit || exchange.RequestID == "" {
+		t.Errorf("intercambio = %s.%s CUIT %d request id %q", exchange.ServiceName, exchange.Operation, exchange.Cuit, exchange.RequestID)
+	}
+	if !bytes.Contains(exchange.RequestBody, []byte("loginCms")) || !bytes.Contains(exchange.ResponseBody, []byte(ticket.Token)) {
+		t.Errorf("el intercambio no contiene el sobre enviado y recibido:\n%s", exchange.Dump())
+	}
+
+	redacted := redactExchange(exchange).Dump()
+	if bytes.Contains(redacted, []byte(ticket.Token)) || bytes.Contains(redacted, []byte(ticket.Sign)) {
+		t.Errorf("el intercambio registrado contiene las credenciales del ticket:\n%s", redacted)
+	}
+	if !bytes.Contains(redacted, []byte("&lt;token&gt;"+redactedValue+"&lt;/token&gt;")) {
+		t.Errorf("no se ocultó el token:\n%s", redacted)
+	}
+}
@@ -1,119 +1,89 @@
 package services
 
 import (
-	"crypto/tls"
-	"encoding/xml"
+	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
-	"reflect"
-	"strings"
-	"time"
 
 	"github.com/hooklift/gowsdl/soap"
 	"github.com/sehogas/goarca/ws/wscoem"
 )
 
 type Wscoem struct {
-	logger        *slog.Logger
-	serviceName   string
-	environment   Environment
-	url           string
-	cuit          int64
-	tipoAgente    string
-	rol           string
-	soapTlsConfig tls.Config
-	printXML      bool
-	saveXML       bool
+	logger      *slog.Logger
+	serviceName string
+	environment Environment
+	url         string
+	tenant      *Tenant
+	cuit        int64
+	tipoAgente  string
+	rol         string
+	httpClient  *http.Client
+	service     wscoem.WgescomunicacionembarqueSoap
 }
 
-func NewWscoem(logger *slog.Logger, environment Environment, cuit int64, printXML, saveXML bool) (*Wscoem, error) {
+func NewWscoem(logger *slog.Logger, environment Environment, tenant *Tenant, printXML, saveXML bool) (*Wscoem, error) {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	}
 
-	var url string
-	if environment == PRODUCTION {
-		url = URLWSCOEMProduction
-	} else {
-		url = URLWSCOEMTesting
-	}
+	url := ServiceURL(environment, EndpointWSCOEM)
 
-	if len(os.Getenv("WSCOEM_TIPO_AGENTE")) != 4 {
-		return nil, fmt.Errorf("missing environment variable WSCOEM_TIPO_AGENTE")
+	if tenant.Wscoem == nil {
+		return nil, fmt.Errorf("CUIT %d: missing wscoem tipoAgente/rol", tenant.Cuit)
 	}
 
-	if len(os.Getenv("WSCOEM_ROL")) != 4 {
-		return nil, fmt.Errorf("missing or invalid environment variable WSCOEM_ROL")
+	if err := tenant.Wscoem.validate("wscoem"); err != nil {
+		return nil, fmt.Errorf("CUIT %d: %s", tenant.Cuit, err)
 	}
 
-	return &Wscoem{
+	ws := &Wscoem{
 		logger:      logger,
 		serviceName: "wgescomunicacionembarque",
 		environment: environment,
 		url:         url,
-		cuit:        cuit,
-		tipoAgente:  os.Getenv("WSCOEM_TIPO_AGENTE"),
-		rol:         os.Getenv("WSCOEM_ROL"),
-		soapTlsConfig: tls.Config{
-			InsecureSkipVerify: true,
-		},
-		printXML: printXML,
-		saveXML:  saveXML,
-	}, nil
+		tenant:      tenant,
+		cuit:        tenant.Cuit,
+		tipoAgente:  tenant.Wscoem.TipoAgente,
+		rol:         tenant.Wscoem.Rol,
+	}
+	ws.httpClient = newSOAPHTTPClient(ws.serviceName, ws.cuit, url, environment, func(ctx context.Context, client *http.Client) error {
+		probe := &Wscoem{serviceName: ws.serviceName, service: wscoem.NewWgescomunicacionembarqueSoap(soap.NewClient(url, soap.WithHTTPClient(client)))}
+		_, err := probe.Dummy(ctx)
+		return err
+	}, newExchangeCapture(logger, printXML, saveXML))
+	ws.service = wscoem.NewWgescomunicacionembarqueSoap(soap.NewClient(url, soap.WithHTTPClient(ws.httpClient)))
+	return ws, nil
 }
 
-func (ws *Wscoem) PrintAndSaveXML(obj interface{}) {
-	if ws.printXML || ws.saveXML {
-		data, err := xml.MarshalIndent(obj, " ", "  ")
-		if err == nil {
-			if ws.printXML {
-				ws.logger.Info("printXML", "XML", string(data))
-			}
-			if ws.saveXML {
-				path := fmt.Sprintf("xml/%s", ws.serviceName)
-				if _, err := os.Stat(path); os.IsNotExist(err) {
-					if err := os.Mkdir(path, os.ModePerm); err != nil {
-						ws.logger.Error(fmt.Sprintf("Error creating directory [ %s ]", path), "err", err.Error())
-						return
-					}
-				}
-				fileName := fmt.Sprintf("%s/%s_%s.xml", path, strings.ReplaceAll(strings.ReplaceAll(reflect.TypeOf(obj).String(), "*", ""), " ", "_"), strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(time.Now().Format(time.RFC3339), ":", ""), "-", ""), "T", ""))
-				f, err := os.Create(fileName)
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error creating file [ %s ]", fileName), "err", err.Error())
-				}
-				defer f.Close()
-				_, err = f.WriteString(string(data))
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error writing file [ %s ]", fileName), "err", err.Error())
-				}
-				ws.logger.Info("saveXML", "file", fileName)
-			}
-		}
-	}
+// ServiceName devuelve el nombre del servicio ante WSAA.
+func (ws *Wscoem) ServiceName() string {
+	return ws.serviceName
 }
 
-func (ws *Wscoem) Dummy() (*wscoem.ResultadoEjecucionDummy, error) {
-	request := &wscoem.Dummy{}
+func (ws *Wscoem) Dummy(ctx context.Context) (*wscoem.ResultadoEjecucionDummy, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "Dummy")
+	defer cancel()
 
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
+	request := &wscoem.Dummy{}
 
-	response, err := service.Dummy(request)
+	response, err := ws.service.DummyContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.DummyResult, nil
+	return response.DummyResult, businessError(ctx, response.DummyResult)
 }
 
-func (ws *Wscoem) RegistrarCaratula(params *wscoem.RegistrarCaratulaRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) RegistrarCaratula(ctx context.Context, params *wscoem.RegistrarCaratulaRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "RegistrarCaratula")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.RegistrarCaratula{
@@ -129,25 +99,21 @@ func (ws *Wscoem) RegistrarCaratula(params *wscoem.RegistrarCaratulaRequest) (*w
 		ArgRegistrarCaratula: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.RegistrarCaratula(request)
+	response, err := ws.service.RegistrarCaratulaContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.RegistrarCaratulaResult, nil
+	return response.RegistrarCaratulaResult, businessError(ctx, response.RegistrarCaratulaResult)
 }
 
-func (ws *Wscoem) AnularCaratula(params *wscoem.AnularCaratulaRequest) (*wscoem.AnularEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) AnularCaratula(ctx context.Context, params *wscoem.AnularCaratulaRequest) (*wscoem.AnularEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "AnularCaratula")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.AnularCaratula{
@@ -164,25 +130,21 @@ func (ws *Wscoem) AnularCaratula(params *wscoem.AnularCaratulaRequest) (*wscoem.
 		ArgAnularCaratula: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.AnularCaratula(request)
+	response, err := ws.service.AnularCaratulaContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.AnularCaratulaResult, nil
+	return response.AnularCaratulaResult, businessError(ctx, response.AnularCaratulaResult)
 }
 
-func (ws *Wscoem) RectificarCaratula(params *wscoem.RectificarCaratulaRequest) (*wscoem.RectificarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) RectificarCaratula(ctx context.Context, params *wscoem.RectificarCaratulaRequest) (*wscoem.RectificarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "RectificarCaratula")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.RectificarCaratula{
@@ -198,25 +160,21 @@ func (ws *Wscoem) RectificarCaratula(params *wscoem.RectificarCaratulaRequest) (
 		ArgRectificarCaratula: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.RectificarCaratula(request)
+	response, err := ws.service.RectificarCaratulaContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.RectificarCaratulaResult, nil
+	return response.RectificarCaratulaResult, businessError(ctx, response.RectificarCaratulaResult)
 }
 
-func (ws *Wscoem) RegistrarCOEM(params *wscoem.RegistrarCOEMRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) RegistrarCOEM(ctx context.Context, params *wscoem.RegistrarCOEMRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "RegistrarCOEM")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.RegistrarCOEM{
@@ -231,24 +189,22 @@ func (ws *Wscoem) RegistrarCOEM(params *wscoem.RegistrarCOEMRequest) (*wscoem.Re
 		},
 		ArgRegistrarCOEM: params,
 	}
-	ws.PrintAndSaveXML(request)
 
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.RegistrarCOEM(request)
+	response, err := ws.service.RegistrarCOEMContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
-	ws.PrintAndSaveXML(response)
 
-	return response.RegistrarCOEMResult, nil
+	return response.RegistrarCOEMResult, businessError(ctx, response.RegistrarCOEMResult)
 }
 
-func (ws *Wscoem) SolicitarCambioBuque(params *wscoem.SolicitarCambioBuqueRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) SolicitarCambioBuque(ctx context.Context, params *wscoem.SolicitarCambioBuqueRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "SolicitarCambioBuque")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.SolicitarCambioBuque{
@@ -264,25 +220,21 @@ func (ws *Wscoem) SolicitarCambioBuque(params *wscoem.SolicitarCambioBuqueReques
 		ArgSolicitarCambioBuque: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.SolicitarCambioBuque(request)
+	response, err := ws.service.SolicitarCambioBuqueContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.SolicitarCambioBuqueResult, nil
+	return response.SolicitarCambioBuqueResult, businessError(ctx, response.SolicitarCambioBuqueResult)
 }
 
-func (ws *Wscoem) SolicitarCambioFechas(params *wscoem.SolicitarCambioFechasRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) SolicitarCambioFechas(ctx context.Context, params *wscoem.SolicitarCambioFechasRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "SolicitarCambioFechas")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.SolicitarCambioFechas{
@@ -298,25 +250,21 @@ func (ws *Wscoem) SolicitarCambioFechas(params *wscoem.SolicitarCambioFechasRequ
 		ArgSolicitarCambioFechas: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.SolicitarCambioFechas(request)
+	response, err := ws.service.SolicitarCambioFechasContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.SolicitarCambioFechasResult, nil
+	return response.SolicitarCambioFechasResult, businessError(ctx, response.SolicitarCambioFechasResult)
 }
 
-func (ws *Wscoem) SolicitarCambioLOT(params *wscoem.SolicitarCambioLOTRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) SolicitarCambioLOT(ctx context.Context, params *wscoem.SolicitarCambioLOTRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "SolicitarCambioLOT")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.SolicitarCambioLOT{
@@ -332,25 +280,21 @@ func (ws *Wscoem) SolicitarCambioLOT(params *wscoem.SolicitarCambioLOTRequest) (
 		ArgSolicitarCambioLOT: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.SolicitarCambioLOT(request)
+	response, err := ws.service.SolicitarCambioLOTContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.SolicitarCambioLOTResult, nil
+	return response.SolicitarCambioLOTResult, businessError(ctx, response.SolicitarCambioLOTResult)
 }
 
-func (ws *Wscoem) RectificarCOEM(params *wscoem.RectificarCOEMRequest) (*wscoem.RectificarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) RectificarCOEM(ctx context.Context, params *wscoem.RectificarCOEMRequest) (*wscoem.RectificarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "RectificarCOEM")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.RectificarCOEM{
@@ -366,25 +310,21 @@ func (ws *Wscoem) RectificarCOEM(params *wscoem.RectificarCOEMRequest) (*wscoem.
 		ArgRectificarCOEM: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.RectificarCOEM(request)
+	response, err := ws.service.RectificarCOEMContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.RectificarCOEMResult, nil
+	return response.RectificarCOEMResult, businessError(ctx, response.RectificarCOEMResult)
 }
 
-func (ws *Wscoem) CerrarCOEM(params *wscoem.CerrarCOEMRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) CerrarCOEM(ctx context.Context, params *wscoem.CerrarCOEMRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "CerrarCOEM")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.CerrarCOEM{
@@ -400,18 +340,11 @@ func (ws *Wscoem) CerrarCOEM(params *wscoem.CerrarCOEMRequest) (*wscoem.Registra
 		ArgCerrarCOEM: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.CerrarCOEM(request)
+	response, err := ws.service.CerrarCOEMContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
 	// var errs []error
 	// result := ""
 	// for _, e := range response.CerrarCOEMResult.ListaErrores.DetalleError {
@@ -422,13 +355,16 @@ func (ws *Wscoem) CerrarCOEM(params *wscoem.CerrarCOEMRequest) (*wscoem.Registra
 	// 	}
 	// }
 
-	return response.CerrarCOEMResult, nil
+	return response.CerrarCOEMResult, businessError(ctx, response.CerrarCOEMResult)
 }
 
-func (ws *Wscoem) AnularCOEM(params *wscoem.AnularCOEMRequest) (*wscoem.AnularEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) AnularCOEM(ctx context.Context, params *wscoem.AnularCOEMRequest) (*wscoem.AnularEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "AnularCOEM")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.AnularCOEM{
@@ -444,25 +380,21 @@ func (ws *Wscoem) AnularCOEM(params *wscoem.AnularCOEMRequest) (*wscoem.AnularEm
 		ArgAnularCOEM: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.AnularCOEM(request)
+	response, err := ws.service.AnularCOEMContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.AnularCOEMResult, nil
+	return response.AnularCOEMResult, businessError(ctx, response.AnularCOEMResult)
 }
 
-func (ws *Wscoem) SolicitarAnulacionCOEM(params *wscoem.SolicitarAnulacionCOEMRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) SolicitarAnulacionCOEM(ctx context.Context, params *wscoem.SolicitarAnulacionCOEMRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "SolicitarAnulacionCOEM")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.SolicitarAnulacionCOEM{
@@ -478,25 +410,21 @@ func (ws *Wscoem) SolicitarAnulacionCOEM(params *wscoem.SolicitarAnulacionCOEMRe
 		ArgSolicitarAnulacionCOEM: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.SolicitarAnulacionCOEM(request)
+	response, err := ws.service.SolicitarAnulacionCOEMContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.SolicitarAnulacionCOEMResult, nil
+	return response.SolicitarAnulacionCOEMResult, businessError(ctx, response.SolicitarAnulacionCOEMResult)
 }
 
-func (ws *Wscoem) SolicitarNoABordo(params *wscoem.SolicitarNoABordoRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) SolicitarNoABordo(ctx context.Context, params *wscoem.SolicitarNoABordoRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "SolicitarNoABordo")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.SolicitarNoABordo{
@@ -512,25 +440,21 @@ func (ws *Wscoem) SolicitarNoABordo(params *wscoem.SolicitarNoABordoRequest) (*w
 		ArgSolicitarNoABordo: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.SolicitarNoABordo(request)
+	response, err := ws.service.SolicitarNoABordoContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.SolicitarNoABordoResult, nil
+	return response.SolicitarNoABordoResult, businessError(ctx, response.SolicitarNoABordoResult)
 }
 
-func (ws *Wscoem) SolicitarCierreCargaContoBulto(params *wscoem.SolicitarCierreCargaContoBultoRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) SolicitarCierreCargaContoBulto(ctx context.Context, params *wscoem.SolicitarCierreCargaContoBultoRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "SolicitarCierreCargaContoBulto")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.SolicitarCierreCargaContoBulto{
@@ -546,25 +470,21 @@ func (ws *Wscoem) SolicitarCierreCargaContoBulto(params *wscoem.SolicitarCierreC
 		ArgSolicitarCierreCargaContoBulto: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.SolicitarCierreCargaContoBulto(request)
+	response, err := ws.service.SolicitarCierreCargaContoBultoContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.SolicitarCierreCargaContoBultoResult, nil
+	return response.SolicitarCierreCargaContoBultoResult, businessError(ctx, response.SolicitarCierreCargaContoBultoResult)
 }
 
-func (ws *Wscoem) SolicitarCierreCargaGranel(params *wscoem.SolicitarCierreCargaGranelRequest) (*wscoem.RegistrarEmbarqueRta, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoem) SolicitarCierreCargaGranel(ctx context.Context, params *wscoem.SolicitarCierreCargaGranelRequest) (*wscoem.RegistrarEmbarqueRta, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "SolicitarCierreCargaGranel")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoem.SolicitarCierreCargaGranel{
@@ -580,17 +500,10 @@ func (ws *Wscoem) SolicitarCierreCargaGranel(params *wscoem.SolicitarCierreCarga
 		ArgSolicitarCierreCargaGranel: params,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoem.NewWgescomunicacionembarqueSoap(client)
-
-	response, err := service.SolicitarCierreCargaGranel(request)
+	response, err := ws.service.SolicitarCierreCargaGranelContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.SolicitarCierreCargaGranelResult, nil
+	return response.SolicitarCierreCargaGranelResult, businessError(ctx, response.SolicitarCierreCargaGranelResult)
 }
@@ -1,119 +1,89 @@
 package services
 
 import (
-	"crypto/tls"
-	"encoding/xml"
+	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
-	"reflect"
-	"strings"
-	"time"
 
 	"github.com/hooklift/gowsdl/soap"
 	"github.com/sehogas/goarca/ws/wscoemcons"
 )
 
 type Wscoemcons struct {
-	logger        *slog.Logger
-	serviceName   string
-	environment   Environment
-	url           string
-	cuit          int64
-	tipoAgente    string
-	rol           string
-	soapTlsConfig tls.Config
-	printXML      bool
-	saveXML       bool
+	logger      *slog.Logger
+	serviceName string
+	environment Environment
+	url         string
+	tenant      *Tenant
+	cuit        int64
+	tipoAgente  string
+	rol         string
+	httpClient  *http.Client
+	service     wscoemcons.WconscomunicacionembarqueSoap
 }
 
-func NewWscoemcons(logger *slog.Logger, environment Environment, cuit int64, printXML, saveXML bool) (*Wscoemcons, error) {
+func NewWscoemcons(logger *slog.Logger, environment Environment, tenant *Tenant, printXML, saveXML bool) (*Wscoemcons, error) {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	}
 
-	var url string
-	if environment == PRODUCTION {
-		url = URLWSCOEMConsProduction
-	} else {
-		url = URLWSCOEMConsTesting
-	}
+	url := ServiceURL(environment, EndpointWSCOEMCons)
 
-	if len(os.Getenv("WSCOEM_TIPO_AGENTE")) != 4 {
-		return nil, fmt.Errorf("missing environment variable WSCOEM_TIPO_AGENTE")
+	if tenant.Wscoem == nil {
+		return nil, fmt.Errorf("CUIT %d: missing wscoem tipoAgente/rol", tenant.Cuit)
 	}
 
-	if len(os.Getenv("WSCOEM_ROL")) != 4 {
-		return nil, fmt.Errorf("missing or invalid environment variable WSCOEM_ROL")
+	if err := tenant.Wscoem.validate("wscoem"); err != nil {
+		return nil, fmt.Errorf("CUIT %d: %s", tenant.Cuit, err)
 	}
 
-	return &Wscoemcons{
+	ws := &Wscoemcons{
 		logger:      logger,
 		serviceName: "wconscomunicacionembarque",
 		environment: environment,
-		cuit:        cuit,
+		tenant:      tenant,
+		cuit:        tenant.Cuit,
 		url:         url,
-		tipoAgente:  os.Getenv("WSCOEM_TIPO_AGENTE"),
-		rol:         os.Getenv("WSCOEM_ROL"),
-		soapTlsConfig: tls.Config{
-			InsecureSkipVerify: true,
-		},
-		printXML: printXML,
-		saveXML:  saveXML,
-	}, nil
+		tipoAgente:  tenant.Wscoem.TipoAgente,
+		rol:         tenant.Wscoem.Rol,
+	}
+	ws.httpClient = newSOAPHTTPClient(ws.serviceName, ws.cuit, url, environment, func(ctx context.Context, client *http.Client) error {
+		probe := &Wscoemcons{serviceName: ws.serviceName, service: wscoemcons.NewWconscomunicacionembarqueSoap(soap.NewClient(url, soap.WithHTTPClient(client)))}
+		_, err := probe.Dummy(ctx)
+		return err
+	}, newExchangeCapture(logger, printXML, saveXML))
+	ws.service = wscoemcons.NewWconscomunicacionembarqueSoap(soap.NewClient(url, soap.WithHTTPClient(ws.httpClient)))
+	return ws, nil
 }
 
-func (ws *Wscoemcons) PrintAndSaveXML(obj interface{}) {
-	if ws.printXML || ws.saveXML {
-		data, err := xml.MarshalIndent(obj, " ", "  ")
-		if err == nil {
-			if ws.printXML {
-				ws.logger.Info("printXML", "XML", string(data))
-			}
-			if ws.saveXML {
-				path := fmt.Sprintf("xml/%s", ws.serviceName)
-				if _, err := os.Stat(path); os.IsNotExist(err) {
-					if err := os.Mkdir(path, os.ModePerm); err != nil {
-						ws.logger.Error(fmt.Sprintf("Error creating directory [ %s ]", path), "err", err.Error())
-						return
-					}
-				}
-				fileName := fmt.Sprintf("%s/%s_%s.xml", path, strings.ReplaceAll(strings.ReplaceAll(reflect.TypeOf(obj).String(), "*", ""), " ", "_"), strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(time.Now().Format(time.RFC3339), ":", ""), "-", ""), "T", ""))
-				f, err := os.Create(fileName)
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error creating file [ %s ]", fileName), "err", err.Error())
-				}
-				defer f.Close()
-				_, err = f.WriteString(string(data))
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error writing file [ %s ]", fileName), "err", err.Error())
-				}
-				ws.logger.Info("saveXML", "file", fileName)
-			}
-		}
-	}
+// ServiceName devuelve el nombre del servicio ante WSAA.
+func (ws *Wscoemcons) ServiceName() string {
+	return ws.serviceName
 }
 
-func (ws *Wscoemcons) Dummy() (*wscoemcons.ResultadoEjecucionOfDummyOutput, error) {
-	request := &wscoemcons.Dummy{}
+func (ws *Wscoemcons) Dummy(ctx context.Context) (*wscoemcons.ResultadoEjecucionOfDummyOutput, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "Dummy")
+	defer cancel()
 
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoemcons.NewWconscomunicacionembarqueSoap(client)
+	request := &wscoemcons.Dummy{}
 
-	response, err := service.Dummy(request)
+	response, err := ws.service.DummyContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.DummyResult, nil
+	return response.DummyResult, businessError(ctx, response.DummyResult)
 }
 
-func (ws *Wscoemcons) ObtenerConsultaEstadosCOEM(identificadorCaratula string) (*wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoemcons) ObtenerConsultaEstadosCOEM(ctx context.Context, identificadorCaratula string) (*wscoemcons.ResultadoEjecucionOfResultadoEstadoProceso, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ObtenerConsultaEstadosCOEM")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoemcons.ObtenerConsultaEstadosCOEM{
@@ -129,25 +99,21 @@ func (ws *Wscoemcons) ObtenerConsultaEstadosCOEM(identificadorCaratula string) (
 		IdentificadorCabecera: identificadorCaratula,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoemcons.NewWconscomunicacionembarqueSoap(client)
-
-	response, err := service.ObtenerConsultaEstadosCOEM(request)
+	response, err := ws.service.ObtenerConsultaEstadosCOEMContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ObtenerConsultaEstadosCOEMResult, nil
+	return response.ObtenerConsultaEstadosCOEMResult, businessError(ctx, response.ObtenerConsultaEstadosCOEMResult)
 }
 
-func (ws *Wscoemcons) ObtenerConsultaNoAbordo(identificadorCaratula string) (*wscoemcons.ResultadoEjecucionOfResultadoNoAbordoProceso, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoemcons) ObtenerConsultaNoAbordo(ctx context.Context, identificadorCaratula string) (*wscoemcons.ResultadoEjecucionOfResultadoNoAbordoProceso, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ObtenerConsultaNoAbordo")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoemcons.ObtenerConsultaNoAbordo{
@@ -163,25 +129,21 @@ func (ws *Wscoemcons) ObtenerConsultaNoAbordo(identificadorCaratula string) (*ws
 		IdentificadorCabecera: identificadorCaratula,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoemcons.NewWconscomunicacionembarqueSoap(client)
-
-	response, err := service.ObtenerConsultaNoAbordo(request)
+	response, err := ws.service.ObtenerConsultaNoAbordoContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ObtenerConsultaNoAbordoResult, nil
+	return response.ObtenerConsultaNoAbordoResult, businessError(ctx, response.ObtenerConsultaNoAbordoResult)
 }
 
-func (ws *Wscoemcons) ObtenerConsultaSolicitudes(identificadorCaratula string) (*wscoemcons.ResultadoEjecucionOfResultadoSolicitudProceso, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wscoemcons) ObtenerConsultaSolicitudes(ctx context.Context, identificadorCaratula string) (*wscoemcons.ResultadoEjecucionOfResultadoSolicitudProceso, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "ObtenerConsultaSolicitudes")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wscoemcons.ObtenerConsultaSolicitudes{
@@ -197,17 +159,10 @@ func (ws *Wscoemcons) ObtenerConsultaSolicitudes(identificadorCaratula string) (
 		IdentificadorCabecera: identificadorCaratula,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wscoemcons.NewWconscomunicacionembarqueSoap(client)
-
-	response, err := service.ObtenerConsultaSolicitudes(request)
+	response, err := ws.service.ObtenerConsultaSolicitudesContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.ObtenerConsultaSolicitudesResult, nil
+	return response.ObtenerConsultaSolicitudesResult, businessError(ctx, response.ObtenerConsultaSolicitudesResult)
 }
@@ -1,107 +1,76 @@
 package services
 
 import (
-	"crypto/tls"
-	"encoding/xml"
-	"fmt"
+	"context"
 	"log/slog"
+	"net/http"
 	"os"
-	"reflect"
-	"strings"
-	"time"
 
 	"github.com/hooklift/gowsdl/soap"
 	"github.com/sehogas/goarca/ws/wsfe"
 )
 
 type Wsfe struct {
-	logger        *slog.Logger
-	serviceName   string
-	environment   Environment
-	url           string
-	cuit          int64
-	soapTlsConfig tls.Config
-	printXML      bool
-	saveXML       bool
+	logger      *slog.Logger
+	serviceName string
+	environment Environment
+	url         string
+	tenant      *Tenant
+	cuit        int64
+	httpClient  *http.Client
+	service     wsfe.ServiceSoap
 }
 
-func NewWsfe(logger *slog.Logger, environment Environment, cuit int64, printXML, saveXML bool) (*Wsfe, error) {
+func NewWsfe(logger *slog.Logger, environment Environment, tenant *Tenant, printXML, saveXML bool) (*Wsfe, error) {
 	if logger == nil {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	}
 
-	var url string
-	if environment == PRODUCTION {
-		url = URLWSFEProduction
-	} else {
-		url = URLWSFETesting
-	}
+	url := ServiceURL(environment, EndpointWSFE)
 
-	return &Wsfe{
+	ws := &Wsfe{
 		logger:      logger,
 		serviceName: "wsfe",
 		environment: environment,
 		url:         url,
-		cuit:        cuit,
-		soapTlsConfig: tls.Config{
-			InsecureSkipVerify: true,
-		},
-		printXML: printXML,
-		saveXML:  saveXML,
-	}, nil
+		tenant:      tenant,
+		cuit:        tenant.Cuit,
+	}
+	ws.httpClient = newSOAPHTTPClient(ws.serviceName, ws.cuit, url, environment, func(ctx context.Context, client *http.Client) error {
+		probe := &Wsfe{serviceName: ws.serviceName, service: wsfe.NewServiceSoap(soap.NewClient(url, soap.WithHTTPClient(client)))}
+		_, err := probe.FEDummy(ctx)
+		return err
+	}, newExchangeCapture(logger, printXML, saveXML))
+	ws.service = wsfe.NewServiceSoap(soap.NewClient(url, soap.WithHTTPClient(ws.httpClient)))
+	return ws, nil
 }
 
-func (ws *Wsfe) PrintAndSaveXML(obj interface{}) {
-	if ws.printXML || ws.saveXML {
-		data, err := xml.MarshalIndent(obj, " ", "  ")
-		if err == nil {
-			if ws.printXML {
-				ws.logger.Info("printXML", "XML", string(data))
-			}
-			if ws.saveXML {
-				path := fmt.Sprintf("xml/%s", ws.serviceName)
-				if _, err := os.Stat(path); os.IsNotExist(err) {
-					if err := os.Mkdir(path, os.ModePerm); err != nil {
-						ws.logger.Error(fmt.Sprintf("Error creating directory [ %s ]", path), "err", err.Error())
-						return
-					}
-				}
-				fileName := fmt.Sprintf("%s/%s_%s.xml", path, strings.ReplaceAll(strings.ReplaceAll(reflect.TypeOf(obj).String(), "*", ""), " ", "_"), strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(time.Now().Format(time.RFC3339), ":", ""), "-", ""), "T", ""))
-				f, err := os.Create(fileName)
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error creating file [ %s ]", fileName), "err", err.Error())
-				}
-				defer f.Close()
-				_, err = f.WriteString(string(data))
-				if err != nil {
-					ws.logger.Error(fmt.Sprintf("Error writing file [ %s ]", fileName), "err", err.Error())
-				}
-				ws.logger.Info("saveXML", "file", fileName)
-			}
-		}
-	}
+// ServiceName devuelve el nombre del servicio ante WSAA.
+func (ws *Wsfe) ServiceName() string {
+	return ws.serviceName
 }
 
-func (ws *Wsfe) FEDummy() (*wsfe.DummyResponse, error) {
-	request := &wsfe.FEDummy{}
+func (ws *Wsfe) FEDummy(ctx context.Context) (*wsfe.DummyResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEDummy")
+	defer cancel()
 
-	client := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(client)
+	request := &wsfe.FEDummy{}
 
-	response, err := service.FEDummy(request)
+	response, err := ws.service.FEDummyContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEDummyResult, nil
+	return response.FEDummyResult, businessError(ctx, response.FEDummyResult)
 }
 
-func (ws *Wsfe) FEUltimoComprobanteEmitido(ptoVta int32, cbteTipo int32) (*wsfe.FERecuperaLastCbteResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEUltimoComprobanteEmitido(ctx context.Context, ptoVta int32, cbteTipo int32) (*wsfe.FERecuperaLastCbteResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECompUltimoAutorizado")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wsfe.FECompUltimoAutorizado{
@@ -113,25 +82,21 @@ func (ws *Wsfe) FEUltimoComprobanteEmitido(ptoVta int32, cbteTipo int32) (*wsfe.
 		CbteTipo: cbteTipo,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FECompUltimoAutorizado(request)
+	response, err := ws.service.FECompUltimoAutorizadoContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FECompUltimoAutorizadoResult, nil
+	return response.FECompUltimoAutorizadoResult, businessError(ctx, response.FECompUltimoAutorizadoResult)
 }
 
-func (ws *Wsfe) FECAESolicitar(cab *wsfe.FECabRequest, det []*wsfe.FECAEDetRequest) (*wsfe.FECAEResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FECAESolicitar(ctx context.Context, cab *wsfe.FECabRequest, det []*wsfe.FECAEDetRequest) (*wsfe.FECAEResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECAESolicitar")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wsfe.FECAESolicitar{
@@ -149,222 +114,199 @@ func (ws *Wsfe) FECAESolicitar(cab *wsfe.FECabRequest, det []*wsfe.FECAEDetReque
 		},
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FECAESolicitar(request)
+	response, err := ws.service.FECAESolicitarContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FECAESolicitarResult, nil
+	return response.FECAESolicitarResult, businessError(ctx, response.FECAESolicitarResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposCbte() (*wsfe.CbteTipoResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposCbte(ctx context.Context) (*wsfe.CbteTipoResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposCbte")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposCbte(&wsfe.FEParamGetTiposCbte{
+	response, err := ws.service.FEParamGetTiposCbteContext(ctx, &wsfe.FEParamGetTiposCbte{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposCbteResult, nil
+	return response.FEParamGetTiposCbteResult, businessError(ctx, response.FEParamGetTiposCbteResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposConcepto() (*wsfe.ConceptoTipoResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposConcepto(ctx context.Context) (*wsfe.ConceptoTipoResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposConcepto")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposConcepto(&wsfe.FEParamGetTiposConcepto{
+	response, err := ws.service.FEParamGetTiposConceptoContext(ctx, &wsfe.FEParamGetTiposConcepto{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposConceptoResult, nil
+	return response.FEParamGetTiposConceptoResult, businessError(ctx, response.FEParamGetTiposConceptoResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposDoc() (*wsfe.DocTipoResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposDoc(ctx context.Context) (*wsfe.DocTipoResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposDoc")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposDoc(&wsfe.FEParamGetTiposDoc{
+	response, err := ws.service.FEParamGetTiposDocContext(ctx, &wsfe.FEParamGetTiposDoc{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposDocResult, nil
+	return response.FEParamGetTiposDocResult, businessError(ctx, response.FEParamGetTiposDocResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposIva() (*wsfe.IvaTipoResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposIva(ctx context.Context) (*wsfe.IvaTipoResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposIva")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposIva(&wsfe.FEParamGetTiposIva{
+	response, err := ws.service.FEParamGetTiposIvaContext(ctx, &wsfe.FEParamGetTiposIva{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposIvaResult, nil
+	return response.FEParamGetTiposIvaResult, businessError(ctx, response.FEParamGetTiposIvaResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposMonedas() (*wsfe.MonedaResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposMonedas(ctx context.Context) (*wsfe.MonedaResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposMonedas")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposMonedas(&wsfe.FEParamGetTiposMonedas{
+	response, err := ws.service.FEParamGetTiposMonedasContext(ctx, &wsfe.FEParamGetTiposMonedas{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposMonedasResult, nil
+	return response.FEParamGetTiposMonedasResult, businessError(ctx, response.FEParamGetTiposMonedasResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposOpcional() (*wsfe.OpcionalTipoResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposOpcional(ctx context.Context) (*wsfe.OpcionalTipoResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposOpcional")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposOpcional(&wsfe.FEParamGetTiposOpcional{
+	response, err := ws.service.FEParamGetTiposOpcionalContext(ctx, &wsfe.FEParamGetTiposOpcional{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposOpcionalResult, nil
+	return response.FEParamGetTiposOpcionalResult, businessError(ctx, response.FEParamGetTiposOpcionalResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposTributos() (*wsfe.FETributoResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposTributos(ctx context.Context) (*wsfe.FETributoResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposTributos")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposTributos(&wsfe.FEParamGetTiposTributos{
+	response, err := ws.service.FEParamGetTiposTributosContext(ctx, &wsfe.FEParamGetTiposTributos{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposTributosResult, nil
+	return response.FEParamGetTiposTributosResult, businessError(ctx, response.FEParamGetTiposTributosResult)
 }
 
-func (ws *Wsfe) FEParamGetPtosVenta() (*wsfe.FEPtoVentaResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetPtosVenta(ctx context.Context) (*wsfe.FEPtoVentaResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetPtosVenta")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetPtosVenta(&wsfe.FEParamGetPtosVenta{
+	response, err := ws.service.FEParamGetPtosVentaContext(ctx, &wsfe.FEParamGetPtosVenta{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetPtosVentaResult, nil
+	return response.FEParamGetPtosVentaResult, businessError(ctx, response.FEParamGetPtosVentaResult)
 }
 
-func (ws *Wsfe) FEParamGetCotizacion(monId, fchCotiz string) (*wsfe.FECotizacionResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetCotizacion(ctx context.Context, monId, fchCotiz string) (*wsfe.FECotizacionResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetCotizacion")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
 	request := &wsfe.FEParamGetCotizacion{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
@@ -374,46 +316,43 @@ func (ws *Wsfe) FEParamGetCotizacion(monId, fchCotiz string) (*wsfe.FECotizacion
 		FchCotiz: fchCotiz,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	response, err := service.FEParamGetCotizacion(request)
+	response, err := ws.service.FEParamGetCotizacionContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetCotizacionResult, nil
+	return response.FEParamGetCotizacionResult, businessError(ctx, response.FEParamGetCotizacionResult)
 }
 
-func (ws *Wsfe) FECompTotXRequest() (*wsfe.FERegXReqResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FECompTotXRequest(ctx context.Context) (*wsfe.FERegXReqResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECompTotXRequest")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FECompTotXRequest(&wsfe.FECompTotXRequest{
+	response, err := ws.service.FECompTotXRequestContext(ctx, &wsfe.FECompTotXRequest{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FECompTotXRequestResult, nil
+	return response.FECompTotXRequestResult, businessError(ctx, response.FECompTotXRequestResult)
 }
 
-func (ws *Wsfe) FECAEARegInformativo(cab *wsfe.FECabRequest, det []*wsfe.FECAEADetRequest) (*wsfe.FECAEAResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FECAEARegInformativo(ctx context.Context, cab *wsfe.FECabRequest, det []*wsfe.FECAEADetRequest) (*wsfe.FECAEAResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECAEARegInformativo")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
 	request := &wsfe.FECAEARegInformativo{
@@ -431,30 +370,23 @@ func (ws *Wsfe) FECAEARegInformativo(cab *wsfe.FECabRequest, det []*wsfe.FECAEAD
 		},
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FECAEARegInformativo(request)
+	response, err := ws.service.FECAEARegInformativoContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FECAEARegInformativoResult, nil
+	return response.FECAEARegInformativoResult, businessError(ctx, response.FECAEARegInformativoResult)
 }
 
-func (ws *Wsfe) FECAEASinMovimientoConsultar(caea string, ptoVta int32) (*wsfe.FECAEASinMovConsResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FECAEASinMovimientoConsultar(ctx context.Context, caea string, ptoVta int32) (*wsfe.FECAEASinMovConsResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECAEASinMovimientoConsultar")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
 	request := &wsfe.FECAEASinMovimientoConsultar{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
@@ -464,26 +396,103 @@ func (ws *Wsfe) FECAEASinMovimientoConsultar(caea string, ptoVta int32) (*wsfe.F
 		PtoVta: ptoVta,
 	}
 
-	ws.PrintAndSaveXML(request)
+	response, err := ws.service.FECAEASinMovimientoConsultarContext(ctx, request)
+	if err != nil {
+		return nil, operationError(ctx, err)
+	}
+
+	return response.FECAEASinMovimientoConsultarResult, businessError(ctx, response.FECAEASinMovimientoConsultarResult)
+}
+
+// FECAEASolicitar solicita el CAEA de la quincena indicada por periodo (aaaamm) y orden (1: del 1 al 15, 2: del 16 a fin de mes).
+func (ws *Wsfe) FECAEASolicitar(ctx context.Context, periodo int32, orden int16) (*wsfe.FECAEAGetResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECAEASolicitar")
+	defer cancel()
 
-	response, err := service.FECAEASinMovimientoConsultar(request)
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
+	}
+
+	request := &wsfe.FECAEASolicitar{
+		Auth: &wsfe.FEAuthRequest{
+			Token: ticket.Token,
+			Sign:  ticket.Sign,
+			Cuit:  ticket.Cuit},
+		Periodo: periodo,
+		Orden:   orden,
 	}
 
-	ws.PrintAndSaveXML(response)
+	response, err := ws.service.FECAEASolicitarContext(ctx, request)
+	if err != nil {
+		return nil, operationError(ctx, err)
+	}
 
-	return response.FECAEASinMovimientoConsultarResult, nil
+	return response.FECAEASolicitarResult, businessError(ctx, response.FECAEASolicitarResult)
 }
 
-func (ws *Wsfe) FECompConsultar(ptoVta, cbteTipo int32, cbteNro int64) (*wsfe.FECompConsultaResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+// FECAEAConsultar consulta el CAEA otorgado para la quincena indicada por periodo (aaaamm) y orden.
+func (ws *Wsfe) FECAEAConsultar(ctx context.Context, periodo int32, orden int16) (*wsfe.FECAEAGetResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECAEAConsultar")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
+	if err != nil {
+		return nil, operationError(ctx, err)
+	}
+
+	request := &wsfe.FECAEAConsultar{
+		Auth: &wsfe.FEAuthRequest{
+			Token: ticket.Token,
+			Sign:  ticket.Sign,
+			Cuit:  ticket.Cuit},
+		Periodo: periodo,
+		Orden:   orden,
+	}
+
+	response, err := ws.service.FECAEAConsultarContext(ctx, request)
+	if err != nil {
+		return nil, operationError(ctx, err)
+	}
+
+	return response.FECAEAConsultarResult, businessError(ctx, response.FECAEAConsultarResult)
+}
+
+// FECAEASinMovimientoInformar informa que el punto de venta no emitió comprobantes con el CAEA.
+func (ws *Wsfe) FECAEASinMovimientoInformar(ctx context.Context, caea string, ptoVta int32) (*wsfe.FECAEASinMovResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECAEASinMovimientoInformar")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
+	if err != nil {
+		return nil, operationError(ctx, err)
+	}
+
+	request := &wsfe.FECAEASinMovimientoInformar{
+		Auth: &wsfe.FEAuthRequest{
+			Token: ticket.Token,
+			Sign:  ticket.Sign,
+			Cuit:  ticket.Cuit},
+		PtoVta: ptoVta,
+		CAEA:   caea,
+	}
+
+	response, err := ws.service.FECAEASinMovimientoInformarContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
+	return response.FECAEASinMovimientoInformarResult, businessError(ctx, response.FECAEASinMovimientoInformarResult)
+}
+
+func (ws *Wsfe) FECompConsultar(ctx context.Context, ptoVta, cbteTipo int32, cbteNro int64) (*wsfe.FECompConsultaResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FECompConsultar")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
+	if err != nil {
+		return nil, operationError(ctx, err)
+	}
 
 	request := &wsfe.FECompConsultar{
 		Auth: &wsfe.FEAuthRequest{
@@ -497,75 +506,67 @@ func (ws *Wsfe) FECompConsultar(ptoVta, cbteTipo int32, cbteNro int64) (*wsfe.FE
 		},
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	response, err := service.FECompConsultar(request)
+	response, err := ws.service.FECompConsultarContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FECompConsultarResult, nil
+	return response.FECompConsultarResult, businessError(ctx, response.FECompConsultarResult)
 }
 
-func (ws *Wsfe) FEParamGetTiposPaises() (*wsfe.FEPaisResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetTiposPaises(ctx context.Context) (*wsfe.FEPaisResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetTiposPaises")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetTiposPaises(&wsfe.FEParamGetTiposPaises{
+	response, err := ws.service.FEParamGetTiposPaisesContext(ctx, &wsfe.FEParamGetTiposPaises{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetTiposPaisesResult, nil
+	return response.FEParamGetTiposPaisesResult, businessError(ctx, response.FEParamGetTiposPaisesResult)
 }
 
-func (ws *Wsfe) FEParamGetActividades() (*wsfe.FEActividadesResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetActividades(ctx context.Context) (*wsfe.FEActividadesResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetActividades")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
-	response, err := service.FEParamGetActividades(&wsfe.FEParamGetActividades{
+	response, err := ws.service.FEParamGetActividadesContext(ctx, &wsfe.FEParamGetActividades{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
 			Sign:  ticket.Sign,
 			Cuit:  ticket.Cuit},
 	})
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetActividadesResult, nil
+	return response.FEParamGetActividadesResult, businessError(ctx, response.FEParamGetActividadesResult)
 }
 
-func (ws *Wsfe) FEParamGetCondicionIvaReceptor(claseCmp string) (*wsfe.CondicionIvaReceptorResponse, error) {
-	ticket, err := GetTA(ws.environment, ws.serviceName, ws.cuit)
+func (ws *Wsfe) FEParamGetCondicionIvaReceptor(ctx context.Context, claseCmp string) (*wsfe.CondicionIvaReceptorResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, ws.serviceName, "FEParamGetCondicionIvaReceptor")
+	defer cancel()
+
+	ticket, err := GetTA(ctx, ws.environment, ws.serviceName, ws.tenant)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	conexion := soap.NewClient(ws.url, soap.WithTLS(&ws.soapTlsConfig))
-	service := wsfe.NewServiceSoap(conexion)
-
 	request := &wsfe.FEParamGetCondicionIvaReceptor{
 		Auth: &wsfe.FEAuthRequest{
 			Token: ticket.Token,
@@ -574,14 +575,10 @@ func (ws *Wsfe) FEParamGetCondicionIvaReceptor(claseCmp string) (*wsfe.Condicion
 		ClaseCmp: claseCmp,
 	}
 
-	ws.PrintAndSaveXML(request)
-
-	response, err := service.FEParamGetCondicionIvaReceptor(request)
+	response, err := ws.service.FEParamGetCondicionIvaReceptorContext(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, operationError(ctx, err)
 	}
 
-	ws.PrintAndSaveXML(response)
-
-	return response.FEParamGetCondicionIvaReceptorResult, nil
+	return response.FEParamGetCondicionIvaReceptorResult, businessError(ctx, response.FEParamGetCondicionIvaReceptorResult)
 }
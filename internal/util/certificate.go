@@ -0,0 +1,86 @@
+package util
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidCuit verifica el dígito verificador (módulo 11) de un CUIT/CUIL.
+func ValidCuit(cuit int64) bool {
+	digits := strconv.FormatInt(cuit, 10)
+	if len(digits) != 11 {
+		return false
+	}
+	weights := []int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	check := 11 - sum%11
+	switch check {
+	case 11:
+		check = 0
+	case 10:
+		// ARCA no asigna CUIT con dígito 10 (en su lugar cambia el prefijo a 23 o 33).
+		return false
+	}
+	return int(digits[10]-'0') == check
+}
+
+// CuitFromCertificate devuelve el CUIT del campo serialNumber del sujeto ("CUIT 20999999992"),
+// que es como ARCA identifica al titular del certificado.
+func CuitFromCertificate(certificate *x509.Certificate) (int64, error) {
+	serial := strings.TrimSpace(certificate.Subject.SerialNumber)
+	value, found := strings.CutPrefix(strings.ToUpper(serial), "CUIT")
+	if !found {
+		return 0, fmt.Errorf("el sujeto del certificado no contiene serialNumber=CUIT (%s)", certificate.Subject)
+	}
+	cuit, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("serialNumber del certificado inválido [%s]", serial)
+	}
+	return cuit, nil
+}
+
+// CheckCertificate verifica que el certificado esté vigente en now y pertenezca al CUIT.
+func CheckCertificate(certificate *x509.Certificate, cuit int64, now time.Time) error {
+	if now.Before(certificate.NotBefore) {
+		return fmt.Errorf("el certificado (%s) no es válido hasta %s", certificate.Subject, certificate.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(certificate.NotAfter) {
+		return fmt.Errorf("el certificado (%s) venció el %s", certificate.Subject, certificate.NotAfter.Format(time.RFC3339))
+	}
+	certificateCuit, err := CuitFromCertificate(certificate)
+	if err != nil {
+		return err
+	}
+	if certificateCuit != cuit {
+		return fmt.Errorf("el certificado pertenece al CUIT %d y no al CUIT %d", certificateCuit, cuit)
+	}
+	return nil
+}
+
+// CreateCSR genera una solicitud de certificado PEM con el sujeto que exige ARCA:
+// C=AR, O=organization, CN=commonName y serialNumber=CUIT <cuit>.
+func CreateCSR(key crypto.Signer, cuit int64, organization, commonName string) ([]byte, error) {
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			Country:      []string{"AR"},
+			Organization: []string{organization},
+			CommonName:   commonName,
+			SerialNumber: fmt.Sprintf("CUIT %d", cuit),
+		},
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("CreateCSR: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
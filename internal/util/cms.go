@@ -0,0 +1,162 @@
+package util
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+var (
+	oidData                 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidAttributeContentType = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeDigest      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttributeSigningTime = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidSHA256               = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidRSAEncryption        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	asn1Null                = asn1.RawValue{Tag: asn1.TagNull}
+)
+
+type cmsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type cmsSignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo cmsContentInfo
+	Certificates     asn1.RawValue
+	SignerInfos      []cmsSignerInfo `asn1:"set"`
+}
+
+type cmsIssuerAndSerial struct {
+	Issuer asn1.RawValue
+	Serial *big.Int
+}
+
+type cmsSignerInfo struct {
+	Version            int
+	Sid                cmsIssuerAndSerial
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+}
+
+type cmsAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+// EncodeCMS devuelve el content firmado PKCS#7 (CMS SignedData con el contenido incluido y SHA-256).
+// La firma se delega en signer, que puede ser una clave en memoria, un token PKCS#11 u otro proceso;
+// sólo se admiten claves públicas RSA (PKCS#1 v1.5) o ECDSA. No se utiliza go.mozilla.org/pkcs7 porque elige el
+// algoritmo de firma según el tipo concreto de la clave privada (*rsa.PrivateKey o *ecdsa.PrivateKey) y rechaza
+// los signer de PKCS#11; las pruebas verifican el resultado con esa biblioteca.
+func EncodeCMS(content []byte, certificate *x509.Certificate, signer crypto.Signer) ([]byte, error) {
+	var signatureAlgorithm pkix.AlgorithmIdentifier
+	switch signer.Public().(type) {
+	case *rsa.PublicKey:
+		signatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1Null}
+	case *ecdsa.PublicKey:
+		signatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}
+	default:
+		return nil, fmt.Errorf("encodeCMS: Tipo de clave no soportado %T", signer.Public())
+	}
+
+	digest := sha256.Sum256(content)
+	attributes, err := cmsSignedAttributes(digest[:], time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("encodeCMS: No se pudo armar atributos firmados: %s", err)
+	}
+
+	// Se firman los atributos codificados como SET OF (RFC 5652, sección 5.4).
+	toSign, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: attributes})
+	if err != nil {
+		return nil, fmt.Errorf("encodeCMS: No se pudo codificar atributos firmados: %s", err)
+	}
+	attributesDigest := sha256.Sum256(toSign)
+	signature, err := signer.Sign(rand.Reader, attributesDigest[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("encodeCMS: No se pudo firmar mensaje: %s", err)
+	}
+
+	eContent, err := asn1.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("encodeCMS: No se pudo codificar contenido: %s", err)
+	}
+	digestAlgorithm := pkix.AlgorithmIdentifier{Algorithm: oidSHA256}
+	signedData := cmsSignedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{digestAlgorithm},
+		EncapContentInfo: cmsContentInfo{
+			ContentType: oidData,
+			Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: eContent},
+		},
+		Certificates: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certificate.Raw},
+		SignerInfos: []cmsSignerInfo{{
+			Version:            1,
+			Sid:                cmsIssuerAndSerial{Issuer: asn1.RawValue{FullBytes: certificate.RawIssuer}, Serial: certificate.SerialNumber},
+			DigestAlgorithm:    digestAlgorithm,
+			SignedAttrs:        asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attributes},
+			SignatureAlgorithm: signatureAlgorithm,
+			Signature:          signature,
+		}},
+	}
+
+	inner, err := asn1.Marshal(signedData)
+	if err != nil {
+		return nil, fmt.Errorf("encodeCMS: No se pudo codificar SignedData: %s", err)
+	}
+	cms, err := asn1.Marshal(cmsContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: inner},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encodeCMS: No se pudo finalizar de firmar mensaje: %s", err)
+	}
+	return cms, nil
+}
+
+// cmsSignedAttributes devuelve los atributos firmados (contentType, signingTime y messageDigest)
+// concatenados en el orden DER de SET OF.
+func cmsSignedAttributes(digest []byte, signingTime time.Time) ([]byte, error) {
+	values := []struct {
+		oid   asn1.ObjectIdentifier
+		value any
+	}{
+		{oidAttributeContentType, oidData},
+		{oidAttributeSigningTime, signingTime.UTC()},
+		{oidAttributeDigest, digest},
+	}
+
+	encoded := make([][]byte, 0, len(values))
+	for _, v := range values {
+		value, err := asn1.Marshal(v.value)
+		if err != nil {
+			return nil, err
+		}
+		attribute, err := asn1.Marshal(cmsAttribute{
+			Type:   v.oid,
+			Values: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: value},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, attribute)
+	}
+	slices.SortFunc(encoded, bytes.Compare)
+	return bytes.Join(encoded, nil), nil
+}
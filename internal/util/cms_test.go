@@ -0,0 +1,118 @@
+package util
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// opaqueSigner oculta el tipo concreto de la clave, como lo hace el firmante PKCS#11.
+type opaqueSigner struct {
+	signer crypto.Signer
+}
+
+func (s opaqueSigner) Public() crypto.PublicKey {
+	return s.signer.Public()
+}
+
+func (s opaqueSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.signer.Sign(rand, digest, opts)
+}
+
+// selfSigned devuelve un certificado autofirmado con la clave de signer.
+func selfSigned(t *testing.T, signer crypto.Signer) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "goarca", SerialNumber: "CUIT 20123456786"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
+
+// verifyCMS verifica con go.mozilla.org/pkcs7 que cms es un SignedData válido de content firmado por certificate.
+func verifyCMS(t *testing.T, cms, content []byte, certificate *x509.Certificate) {
+	t.Helper()
+	p7, err := pkcs7.Parse(cms)
+	if err != nil {
+		t.Fatalf("pkcs7.Parse() = %s", err)
+	}
+	if err := p7.Verify(); err != nil {
+		t.Fatalf("Verify() = %s", err)
+	}
+	if !bytes.Equal(p7.Content, content) {
+		t.Errorf("contenido = %q, se esperaba %q", p7.Content, content)
+	}
+	if signer := p7.GetOnlySigner(); signer == nil || !signer.Equal(certificate) {
+		t.Error("el firmante no es el certificado del tenant")
+	}
+}
+
+func TestEncodeCMS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte(`<?xml version="1.0" encoding="UTF-8"?><loginTicketRequest version="1.0"><header><uniqueId>1</uniqueId></header><service>wsfe</service></loginTicketRequest>`)
+
+	for _, c := range []struct {
+		name   string
+		signer crypto.Signer
+	}{
+		{"RSA", rsaKey},
+		{"ECDSA", ecKey},
+		{"RSA opaco", opaqueSigner{rsaKey}},
+		{"ECDSA opaco", opaqueSigner{ecKey}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			certificate := selfSigned(t, c.signer)
+			cms, err := EncodeCMS(content, certificate, c.signer)
+			if err != nil {
+				t.Fatalf("EncodeCMS() = %s", err)
+			}
+			verifyCMS(t, cms, content, certificate)
+		})
+	}
+
+	t.Run("otra clave", func(t *testing.T) {
+		other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cms, err := EncodeCMS(content, selfSigned(t, ecKey), other)
+		if err != nil {
+			t.Fatalf("EncodeCMS() = %s", err)
+		}
+		p7, err := pkcs7.Parse(cms)
+		if err != nil {
+			t.Fatalf("pkcs7.Parse() = %s", err)
+		}
+		if err := p7.Verify(); err == nil {
+			t.Error("Verify() aceptó una firma con una clave distinta a la del certificado")
+		}
+	})
+}
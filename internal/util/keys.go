@@ -0,0 +1,374 @@
+package util
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/ecdsa"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var ErrPassphraseRequired = errors.New("la clave privada está cifrada y no se indicó la frase de paso")
+var ErrKeyMismatch = errors.New("el certificado no corresponde a la clave privada")
+
+// LoadKeyPair lee la clave privada y el certificado ARCA. keyFile puede ser un PEM (PKCS#1, PKCS#8,
+// SEC 1/EC, cifrado o no) o un PKCS#12 (.p12/.pfx) con clave y certificado; en ese caso certificateFile
+// es opcional. Verifica que el certificado corresponda a la clave.
+func LoadKeyPair(keyFile, certificateFile string, passphrase []byte) (crypto.Signer, *x509.Certificate, error) {
+	var key crypto.Signer
+	var certificate *x509.Certificate
+	var err error
+
+	if IsPKCS12File(keyFile) {
+		key, certificate, err = ReadPKCS12(keyFile, passphrase)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		key, err = ReadPrivateKey(keyFile, passphrase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("clave privada [%s]: %s", keyFile, err)
+		}
+	}
+
+	if certificateFile != "" {
+		certificate, err = ReadCertificate(certificateFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("certificado [%s]: %s", certificateFile, err)
+		}
+	}
+	if certificate == nil {
+		return nil, nil, fmt.Errorf("certificado [%s]: no se encontró un certificado", certificateFile)
+	}
+
+	if err := CheckKeyPair(key, certificate); err != nil {
+		return nil, nil, err
+	}
+	return key, certificate, nil
+}
+
+// CheckKeyPair verifica que la clave pública del certificado sea la de la clave privada.
+func CheckKeyPair(key crypto.Signer, certificate *x509.Certificate) error {
+	type equaler interface {
+		Equal(x crypto.PublicKey) bool
+	}
+	pub, ok := key.Public().(equaler)
+	if !ok || !pub.Equal(certificate.PublicKey) {
+		return fmt.Errorf("%w (certificado: %s)", ErrKeyMismatch, certificate.Subject)
+	}
+	return nil
+}
+
+// ReadPassphrase devuelve la frase de paso leída del archivo indicado o, si no hay archivo,
+// del valor de la variable de entorno envName. Devuelve nil si no hay frase de paso.
+func ReadPassphrase(fileName, envName string) ([]byte, error) {
+	if fileName != "" {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("frase de paso: %s", err)
+		}
+		return bytes.TrimRight(data, "\r\n"), nil
+	}
+	if envName != "" && os.Getenv(envName) != "" {
+		return []byte(os.Getenv(envName)), nil
+	}
+	return nil, nil
+}
+
+// IsPKCS12File indica si el archivo es un PKCS#12 según su extensión.
+func IsPKCS12File(file string) bool {
+	ext := strings.ToLower(filepath.Ext(file))
+	return ext == ".p12" || ext == ".pfx"
+}
+
+// ReadPKCS12 lee un archivo PKCS#12 que contiene la clave privada y el certificado.
+func ReadPKCS12(file string, passphrase []byte) (crypto.Signer, *x509.Certificate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, certificate, _, err := pkcs12.DecodeChain(data, string(passphrase))
+	if err != nil {
+		if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+			return nil, nil, fmt.Errorf("PKCS#12 [%s]: frase de paso incorrecta", file)
+		}
+		return nil, nil, fmt.Errorf("PKCS#12 [%s]: %s", file, err)
+	}
+
+	var key crypto.Signer
+	switch k := privateKey.(type) {
+	case *rsa.PrivateKey:
+		key = k
+	case *ecdsa.PrivateKey:
+		key = k
+	default:
+		return nil, nil, fmt.Errorf("PKCS#12 [%s]: tipo de clave no soportado %T", file, privateKey)
+	}
+	return key, certificate, nil
+}
+
+// ReadPrivateKey lee una clave privada PEM en formato PKCS#1, PKCS#8 o SEC 1 (EC). Si la clave
+// está cifrada (PEM tradicional con Proc-Type o "ENCRYPTED PRIVATE KEY") se descifra con passphrase.
+func ReadPrivateKey(file string, passphrase []byte) (crypto.Signer, error) {
+	buf, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var p *pem.Block
+		p, buf = pem.Decode(buf)
+		if p == nil {
+			return nil, errors.New("no pem block found")
+		}
+		if !strings.HasSuffix(p.Type, "PRIVATE KEY") {
+			continue
+		}
+		return parsePrivateKeyBlock(p, passphrase)
+	}
+}
+
+func parsePrivateKeyBlock(p *pem.Block, passphrase []byte) (crypto.Signer, error) {
+	der := p.Bytes
+
+	// Los PEM cifrados tradicionales (openssl genrsa -aes256) están en desuso pero siguen siendo habituales.
+	if x509.IsEncryptedPEMBlock(p) {
+		if len(passphrase) == 0 {
+			return nil, ErrPassphraseRequired
+		}
+		var err error
+		der, err = x509.DecryptPEMBlock(p, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("no se pudo descifrar la clave privada: %s", err)
+		}
+	}
+
+	var key any
+	var err error
+	switch p.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(der)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(der)
+	case "ENCRYPTED PRIVATE KEY":
+		if len(passphrase) == 0 {
+			return nil, ErrPassphraseRequired
+		}
+		der, err = decryptPKCS8(der, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			if k, errPKCS1 := x509.ParsePKCS1PrivateKey(der); errPKCS1 == nil {
+				key, err = k, nil
+			} else if k, errEC := x509.ParseECPrivateKey(der); errEC == nil {
+				key, err = k, nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// WSAA sólo acepta firmas RSA o ECDSA.
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("tipo de clave no soportado %T", key)
+	}
+}
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC     = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8 descifra una clave "ENCRYPTED PRIVATE KEY" (PKCS#8 con PBES2/PBKDF2), que es el
+// formato generado por openssl pkcs8 -topk8 y openssl genpkey -aes256.
+func decryptPKCS8(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("PKCS#8 cifrado inválido: %s", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("PKCS#8 cifrado: algoritmo no soportado %s (sólo PBES2)", info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("PKCS#8 cifrado: parámetros PBES2 inválidos: %s", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("PKCS#8 cifrado: derivación de clave no soportada %s", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("PKCS#8 cifrado: parámetros PBKDF2 inválidos: %s", err)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case kdf.PRF.Algorithm == nil, kdf.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case kdf.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("PKCS#8 cifrado: PRF no soportada %s", kdf.PRF.Algorithm)
+	}
+
+	var keyLen int
+	var newCipher func([]byte) (cipher.Block, error)
+	switch enc := params.EncryptionScheme.Algorithm; {
+	case enc.Equal(oidAES128CBC):
+		keyLen, newCipher = 16, aes.NewCipher
+	case enc.Equal(oidAES192CBC):
+		keyLen, newCipher = 24, aes.NewCipher
+	case enc.Equal(oidAES256CBC):
+		keyLen, newCipher = 32, aes.NewCipher
+	case enc.Equal(oidDESEDE3CBC):
+		keyLen, newCipher = 24, des.NewTripleDESCipher
+	default:
+		return nil, fmt.Errorf("PKCS#8 cifrado: cifrado no soportado %s", enc)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("PKCS#8 cifrado: IV inválido: %s", err)
+	}
+
+	key, err := pbkdf2.Key(prf, string(passphrase), kdf.Salt, kdf.IterationCount, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() || len(info.EncryptedData)%block.BlockSize() != 0 || len(info.EncryptedData) == 0 {
+		return nil, errors.New("PKCS#8 cifrado: datos cifrados inválidos")
+	}
+
+	data := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(data, info.EncryptedData)
+
+	// Quito el relleno PKCS#7; un relleno inválido casi siempre indica una frase de paso incorrecta.
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > block.BlockSize() || !bytes.Equal(data[len(data)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.New("no se pudo descifrar la clave privada: frase de paso incorrecta")
+	}
+	return data[:len(data)-padding], nil
+}
+
+// EncryptPKCS8 cifra una clave privada en formato "ENCRYPTED PRIVATE KEY" (PKCS#8 con PBES2,
+// PBKDF2-HMAC-SHA256 y AES-256-CBC), compatible con openssl pkcs8 -topk8 -v2 aes-256-cbc.
+func EncryptPKCS8(key crypto.Signer, passphrase []byte) (*pem.Block, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrPassphraseRequired
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	const iterations = 100000
+	derived, err := pbkdf2.Key(sha256.New, string(passphrase), salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+
+	padding := aes.BlockSize - len(der)%aes.BlockSize
+	data := append(der, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, data)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: iterations,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1Null},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParams}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData: data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: encrypted}, nil
+}
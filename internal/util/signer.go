@@ -0,0 +1,20 @@
+package util
+
+import "errors"
+
+var ErrPKCS11Unsupported = errors.New("binario compilado sin soporte PKCS#11 (compile con -tags pkcs11)")
+
+// PKCS11Config identifica una clave privada almacenada en un token PKCS#11 (HSM, SoftHSM o token USB).
+// La clave no sale del token: sólo se le pide que firme.
+type PKCS11Config struct {
+	// Module es la ruta de la biblioteca PKCS#11 del fabricante, por ejemplo /usr/lib/softhsm/libsofthsm2.so.
+	Module string `json:"module"`
+	// TokenLabel puede omitirse si hay un único token presente.
+	TokenLabel string `json:"tokenLabel,omitempty"`
+	// La clave se busca por etiqueta (CKA_LABEL), por identificador hexadecimal (CKA_ID) o por ambos.
+	KeyLabel string `json:"keyLabel,omitempty"`
+	KeyID    string `json:"keyId,omitempty"`
+	// El PIN de usuario se lee de PinFile o, si no se indica, de la variable de entorno cuyo nombre es PinEnv.
+	PinFile string `json:"pinFile,omitempty"`
+	PinEnv  string `json:"pinEnv,omitempty"`
+}
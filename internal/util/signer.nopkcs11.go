@@ -0,0 +1,10 @@
+//go:build !pkcs11
+
+package util
+
+import "crypto"
+
+// OpenPKCS11Signer no está disponible sin el build tag pkcs11, que requiere cgo.
+func OpenPKCS11Signer(config *PKCS11Config) (crypto.Signer, error) {
+	return nil, ErrPKCS11Unsupported
+}
@@ -0,0 +1,347 @@
+//go:build pkcs11
+
+package util
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+var (
+	pkcs11Mu      sync.Mutex
+	pkcs11Modules = make(map[string]*pkcs11.Ctx)
+	pkcs11Signers = make(map[PKCS11Config]*pkcs11Signer)
+)
+
+// Prefijos DigestInfo (RFC 8017, sección 9.2) que CKM_RSA_PKCS espera antes del hash.
+var pkcs11DigestInfoPrefix = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+var pkcs11Curves = []struct {
+	oid   asn1.ObjectIdentifier
+	curve elliptic.Curve
+}{
+	{asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}, elliptic.P256()},
+	{asn1.ObjectIdentifier{1, 3, 132, 0, 34}, elliptic.P384()},
+	{asn1.ObjectIdentifier{1, 3, 132, 0, 35}, elliptic.P521()},
+}
+
+// pkcs11Signer firma con una clave RSA o EC que permanece en el token. Mantiene una sesión
+// abierta que se vuelve a abrir si el token la invalida (por ejemplo, al reconectarlo).
+type pkcs11Signer struct {
+	ctx    *pkcs11.Ctx
+	slot   uint
+	pin    string
+	id     []byte
+	label  string
+	public crypto.PublicKey
+
+	mu      sync.Mutex
+	session pkcs11.SessionHandle
+	key     pkcs11.ObjectHandle
+	open    bool
+}
+
+// OpenPKCS11Signer devuelve un crypto.Signer para la clave del token indicada en config.
+// El signer se reutiliza entre llamadas con la misma configuración.
+func OpenPKCS11Signer(config *PKCS11Config) (crypto.Signer, error) {
+	pkcs11Mu.Lock()
+	defer pkcs11Mu.Unlock()
+
+	if signer, exist := pkcs11Signers[*config]; exist {
+		return signer, nil
+	}
+
+	if config.KeyLabel == "" && config.KeyID == "" {
+		return nil, errors.New("PKCS#11: debe indicar keyLabel o keyId")
+	}
+	id, err := hex.DecodeString(config.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#11: keyId inválido: %s", err)
+	}
+	pin, err := ReadPassphrase(config.PinFile, config.PinEnv)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#11: PIN: %s", err)
+	}
+
+	ctx, err := pkcs11Module(config.Module)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := pkcs11Slot(ctx, config.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := &pkcs11Signer{ctx: ctx, slot: slot, pin: string(pin), id: id, label: config.KeyLabel}
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	if err := signer.openSession(); err != nil {
+		return nil, err
+	}
+	if signer.public, err = signer.publicKey(); err != nil {
+		signer.closeSession()
+		return nil, err
+	}
+
+	pkcs11Signers[*config] = signer
+	return signer, nil
+}
+
+func pkcs11Module(module string) (*pkcs11.Ctx, error) {
+	if ctx, exist := pkcs11Modules[module]; exist {
+		return ctx, nil
+	}
+	ctx := pkcs11.New(module)
+	if ctx == nil {
+		return nil, fmt.Errorf("PKCS#11: no se pudo cargar el módulo [%s]", module)
+	}
+	if err := ctx.Initialize(); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		return nil, fmt.Errorf("PKCS#11: no se pudo inicializar el módulo [%s]: %s", module, err)
+	}
+	pkcs11Modules[module] = ctx
+	return ctx, nil
+}
+
+func pkcs11Slot(ctx *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("PKCS#11: no se pudo listar los tokens: %s", err)
+	}
+	if tokenLabel == "" {
+		if len(slots) != 1 {
+			return 0, fmt.Errorf("PKCS#11: hay %d tokens presentes, indique tokenLabel", len(slots))
+		}
+		return slots[0], nil
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if strings.TrimRight(info.Label, " \x00") == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11: no se encontró el token [%s]", tokenLabel)
+}
+
+// openSession abre una sesión, inicia sesión como usuario y busca la clave privada. Requiere s.mu.
+func (s *pkcs11Signer) openSession() error {
+	session, err := s.ctx.OpenSession(s.slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("PKCS#11: no se pudo abrir sesión: %s", err)
+	}
+	if err := s.ctx.Login(session, pkcs11.CKU_USER, s.pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		s.ctx.CloseSession(session)
+		return fmt.Errorf("PKCS#11: no se pudo iniciar sesión en el token: %s", err)
+	}
+
+	keys, err := s.findObjects(session, pkcs11.CKO_PRIVATE_KEY)
+	if err != nil {
+		s.ctx.CloseSession(session)
+		return err
+	}
+	if len(keys) != 1 {
+		s.ctx.CloseSession(session)
+		return fmt.Errorf("PKCS#11: se encontraron %d claves privadas con label [%s] id [%x], se esperaba una", len(keys), s.label, s.id)
+	}
+
+	s.session, s.key, s.open = session, keys[0], true
+	return nil
+}
+
+func (s *pkcs11Signer) closeSession() {
+	if s.open {
+		s.ctx.CloseSession(s.session)
+		s.open = false
+	}
+}
+
+func (s *pkcs11Signer) findObjects(session pkcs11.SessionHandle, class uint) ([]pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_CLASS, class)}
+	if s.label != "" {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_LABEL, s.label))
+	}
+	if len(s.id) > 0 {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_ID, s.id))
+	}
+
+	if err := s.ctx.FindObjectsInit(session, template); err != nil {
+		return nil, fmt.Errorf("PKCS#11: no se pudo buscar la clave: %s", err)
+	}
+	defer s.ctx.FindObjectsFinal(session)
+
+	objects, _, err := s.ctx.FindObjects(session, 2)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#11: no se pudo buscar la clave: %s", err)
+	}
+	return objects, nil
+}
+
+// publicKey lee la clave pública: para RSA de la propia clave privada y para EC del objeto
+// de clave pública con la misma etiqueta e identificador. Requiere s.mu.
+func (s *pkcs11Signer) publicKey() (crypto.PublicKey, error) {
+	attributes, err := s.ctx.GetAttributeValue(s.session, s.key, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, nil)})
+	if err != nil || len(attributes) != 1 {
+		return nil, fmt.Errorf("PKCS#11: no se pudo leer el tipo de clave: %v", err)
+	}
+
+	switch keyType := pkcs11Ulong(attributes[0].Value); keyType {
+	case pkcs11.CKK_RSA:
+		attributes, err := s.ctx.GetAttributeValue(s.session, s.key, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+		})
+		if err != nil || len(attributes) != 2 {
+			return nil, fmt.Errorf("PKCS#11: no se pudo leer la clave pública RSA: %v", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(attributes[0].Value),
+			E: int(new(big.Int).SetBytes(attributes[1].Value).Int64()),
+		}, nil
+
+	case pkcs11.CKK_EC:
+		objects, err := s.findObjects(s.session, pkcs11.CKO_PUBLIC_KEY)
+		if err != nil {
+			return nil, err
+		}
+		if len(objects) != 1 {
+			return nil, fmt.Errorf("PKCS#11: se encontraron %d claves públicas EC con label [%s] id [%x], se esperaba una", len(objects), s.label, s.id)
+		}
+		attributes, err := s.ctx.GetAttributeValue(s.session, objects[0], []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		})
+		if err != nil || len(attributes) != 2 {
+			return nil, fmt.Errorf("PKCS#11: no se pudo leer la clave pública EC: %v", err)
+		}
+		return pkcs11ECPublicKey(attributes[0].Value, attributes[1].Value)
+
+	default:
+		return nil, fmt.Errorf("PKCS#11: tipo de clave no soportado [%d]", keyType)
+	}
+}
+
+func pkcs11ECPublicKey(params, point []byte) (*ecdsa.PublicKey, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &oid); err != nil {
+		return nil, fmt.Errorf("PKCS#11: CKA_EC_PARAMS inválido: %s", err)
+	}
+	// CKA_EC_POINT es un OCTET STRING DER, aunque algunos módulos devuelven el punto sin codificar.
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err != nil || len(rest) > 0 {
+		raw = point
+	}
+	for _, c := range pkcs11Curves {
+		if c.oid.Equal(oid) {
+			public, err := ecdsa.ParseUncompressedPublicKey(c.curve, raw)
+			if err != nil {
+				return nil, fmt.Errorf("PKCS#11: CKA_EC_POINT inválido: %s", err)
+			}
+			return public, nil
+		}
+	}
+	return nil, fmt.Errorf("PKCS#11: curva no soportada %s", oid)
+}
+
+// pkcs11Ulong decodifica un atributo CK_ULONG, que el módulo devuelve en el orden de bytes del host.
+func pkcs11Ulong(b []byte) uint {
+	switch len(b) {
+	case 8:
+		return uint(binary.NativeEndian.Uint64(b))
+	case 4:
+		return uint(binary.NativeEndian.Uint32(b))
+	}
+	return ^uint(0)
+}
+
+func (s *pkcs11Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign firma digest con RSA PKCS#1 v1.5 o ECDSA según el tipo de clave.
+func (s *pkcs11Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var mechanism uint
+	var data []byte
+	switch s.public.(type) {
+	case *rsa.PublicKey:
+		if _, pss := opts.(*rsa.PSSOptions); pss {
+			return nil, errors.New("PKCS#11: firmas RSA-PSS no soportadas")
+		}
+		prefix, exist := pkcs11DigestInfoPrefix[opts.HashFunc()]
+		if !exist {
+			return nil, fmt.Errorf("PKCS#11: hash no soportado %s", opts.HashFunc())
+		}
+		mechanism, data = pkcs11.CKM_RSA_PKCS, append(bytes.Clone(prefix), digest...)
+	default:
+		mechanism, data = pkcs11.CKM_ECDSA, digest
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	signature, err := s.sign(mechanism, data)
+	if err != nil && pkcs11SessionLost(err) {
+		s.closeSession()
+		if err = s.openSession(); err == nil {
+			signature, err = s.sign(mechanism, data)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#11: no se pudo firmar: %s", err)
+	}
+
+	if mechanism == pkcs11.CKM_ECDSA {
+		// El token devuelve r||s; CMS espera la firma ECDSA codificada en DER.
+		half := len(signature) / 2
+		return asn1.Marshal(struct{ R, S *big.Int }{
+			new(big.Int).SetBytes(signature[:half]),
+			new(big.Int).SetBytes(signature[half:]),
+		})
+	}
+	return signature, nil
+}
+
+func (s *pkcs11Signer) sign(mechanism uint, data []byte) ([]byte, error) {
+	if !s.open {
+		if err := s.openSession(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.ctx.SignInit(s.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(mechanism, nil)}, s.key); err != nil {
+		return nil, err
+	}
+	return s.ctx.Sign(s.session, data)
+}
+
+func pkcs11SessionLost(err error) bool {
+	var code pkcs11.Error
+	if !errors.As(err, &code) {
+		return false
+	}
+	switch code {
+	case pkcs11.CKR_SESSION_HANDLE_INVALID, pkcs11.CKR_SESSION_CLOSED, pkcs11.CKR_USER_NOT_LOGGED_IN,
+		pkcs11.CKR_DEVICE_REMOVED, pkcs11.CKR_TOKEN_NOT_PRESENT, pkcs11.CKR_OBJECT_HANDLE_INVALID, pkcs11.CKR_KEY_HANDLE_INVALID:
+		return true
+	}
+	return false
+}
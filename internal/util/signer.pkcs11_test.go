@@ -0,0 +1,158 @@
+//go:build pkcs11
+
+package util
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const softHSMPin = "1234"
+
+// softHSMModules son las rutas habituales de la biblioteca de SoftHSM; SOFTHSM2_MODULE tiene prioridad.
+var softHSMModules = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib64/pkcs11/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+	"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+}
+
+// softHSMToken inicializa un token de SoftHSM en un directorio temporal e importa las claves indicadas
+// por etiqueta, con identificadores 01, 02, ... Omite la prueba si SoftHSM no está instalado.
+func softHSMToken(t *testing.T, keys map[string]crypto.Signer, labels ...string) string {
+	t.Helper()
+	module := os.Getenv("SOFTHSM2_MODULE")
+	if module == "" {
+		for _, candidate := range softHSMModules {
+			if _, err := os.Stat(candidate); err == nil {
+				module = candidate
+				break
+			}
+		}
+	}
+	if module == "" {
+		t.Skip("SoftHSM no está instalado (indique la biblioteca en SOFTHSM2_MODULE)")
+	}
+	if _, err := exec.LookPath("softhsm2-util"); err != nil {
+		t.Skip("softhsm2-util no está instalado")
+	}
+
+	dir := t.TempDir()
+	tokens := filepath.Join(dir, "tokens")
+	if err := os.Mkdir(tokens, 0700); err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(dir, "softhsm2.conf")
+	if err := os.WriteFile(config, []byte("directories.tokendir = "+tokens+"\nobjectstore.backend = file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOFTHSM2_CONF", config)
+	t.Setenv("GOARCA_TEST_PIN", softHSMPin)
+
+	softHSMUtil(t, "--init-token", "--free", "--label", "goarca", "--pin", softHSMPin, "--so-pin", softHSMPin)
+	for i, label := range labels {
+		der, err := x509.MarshalPKCS8PrivateKey(keys[label])
+		if err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(dir, label+".p8")
+		if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+			t.Fatal(err)
+		}
+		softHSMUtil(t, "--import", file, "--token", "goarca", "--label", label, "--id", fmt.Sprintf("%02d", i+1), "--pin", softHSMPin)
+	}
+	return module
+}
+
+func softHSMUtil(t *testing.T, args ...string) {
+	t.Helper()
+	if output, err := exec.Command("softhsm2-util", args...).CombinedOutput(); err != nil {
+		t.Fatalf("softhsm2-util %v: %s\n%s", args, err, output)
+	}
+}
+
+// TestPKCS11SignerSoftHSM firma con claves RSA y EC almacenadas en SoftHSM y verifica las firmas, el
+// certificado emitido con la clave del token y el CMS del login a WSAA.
+func TestPKCS11SignerSoftHSM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]crypto.Signer{"arca-rsa": rsaKey, "arca-ec": ecKey}
+	module := softHSMToken(t, keys, "arca-rsa", "arca-ec")
+
+	tests := []struct {
+		label string
+		keyID string
+	}{
+		{label: "arca-rsa", keyID: "01"},
+		{label: "arca-ec", keyID: "02"},
+	}
+	for _, test := range tests {
+		t.Run(test.label, func(t *testing.T) {
+			signer, err := OpenPKCS11Signer(&PKCS11Config{Module: module, TokenLabel: "goarca", KeyLabel: test.label, KeyID: test.keyID, PinEnv: "GOARCA_TEST_PIN"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			type publicKey interface{ Equal(crypto.PublicKey) bool }
+			if !signer.Public().(publicKey).Equal(keys[test.label].Public()) {
+				t.Fatalf("Public() no coincide con la clave importada")
+			}
+
+			digest := sha256.Sum256([]byte("loginTicketRequest"))
+			signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch public := signer.Public().(type) {
+			case *rsa.PublicKey:
+				err = rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature)
+			case *ecdsa.PublicKey:
+				if !ecdsa.VerifyASN1(public, digest[:], signature) {
+					t.Fatalf("firma ECDSA inválida")
+				}
+			}
+			if err != nil {
+				t.Fatalf("firma inválida: %s", err)
+			}
+
+			// Certificado autofirmado con la clave del token, como el que se usa en el login a WSAA.
+			certificate := selfSigned(t, signer)
+			if err := certificate.CheckSignatureFrom(certificate); err != nil {
+				t.Fatalf("certificado con firma inválida: %s", err)
+			}
+			content := []byte("<loginTicketRequest/>")
+			cms, err := EncodeCMS(content, certificate, signer)
+			if err != nil {
+				t.Fatalf("EncodeCMS() = %s", err)
+			}
+			verifyCMS(t, cms, content, certificate)
+
+			// La misma configuración reutiliza el signer abierto.
+			again, err := OpenPKCS11Signer(&PKCS11Config{Module: module, TokenLabel: "goarca", KeyLabel: test.label, KeyID: test.keyID, PinEnv: "GOARCA_TEST_PIN"})
+			if err != nil || again != signer {
+				t.Errorf("OpenPKCS11Signer() no reutilizó el signer: %v", err)
+			}
+		})
+	}
+
+	if _, err := OpenPKCS11Signer(&PKCS11Config{Module: module, TokenLabel: "goarca", KeyLabel: "inexistente", PinEnv: "GOARCA_TEST_PIN"}); err == nil {
+		t.Errorf("OpenPKCS11Signer() con una clave inexistente no devolvió error")
+	}
+}
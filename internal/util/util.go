@@ -1,59 +1,16 @@
 package util
 
 import (
-	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
 	"encoding/xml"
-	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"os"
 	"strings"
-
-	"go.mozilla.org/pkcs7"
 )
 
-// EncodeCMS devuelve el content firmado PKCS#7
-func EncodeCMS(content []byte, certificate *x509.Certificate, privateKey *rsa.PrivateKey) ([]byte, error) {
-
-	signedData, err := pkcs7.NewSignedData(content)
-	if err != nil {
-		return nil, fmt.Errorf("encodeCMS: No se pudo inicializar SignedData. %s", err)
-	}
-
-	if err := signedData.AddSigner(certificate, privateKey, pkcs7.SignerInfoConfig{}); err != nil {
-		return nil, fmt.Errorf("encodeCMS: No se pudo agregar firmante: %s", err)
-	}
-
-	detachedSignature, err := signedData.Finish()
-	if err != nil {
-		return nil, fmt.Errorf("encodeCMS: No se pudo finalizar de firmar mensaje: %s", err)
-	}
-
-	return detachedSignature, nil
-}
-
-func ReadPrivateKey(file string) (any, error) {
-	f, err := os.Open(file)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	buf, err := io.ReadAll(f)
-	if err != nil {
-		return nil, err
-	}
-	p, _ := pem.Decode(buf)
-	if p == nil {
-		return nil, errors.New("no pem block found")
-	}
-	return x509.ParsePKCS8PrivateKey(p.Bytes)
-}
-
 func ReadCertificate(file string) (*x509.Certificate, error) {
 	var certificate *x509.Certificate
 
@@ -113,3 +70,14 @@ func GetLogLevelFromEnv() slog.Level {
 		return slog.LevelInfo
 	}
 }
+
+// SplitList separa una lista de valores separados por comas, descartando los vacíos.
+func SplitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}